@@ -1,6 +1,6 @@
 resource "zabbix_trigger" "icmp_loss" {
   description = "ICMP ping loss on ubuntu01"
-  expression  = "{ubuntu01:icmpping.max(5m)}=0"
+  expression  = "max(/ubuntu01/icmpping,5m)=0"
   priority    = "4" # high
   enabled     = true
 }
@@ -0,0 +1,90 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/rushiii/terraform-provider-zabbix/internal/zabbix"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ datasource.DataSource              = &mediaTypeDataSource{}
+	_ datasource.DataSourceWithConfigure = &mediaTypeDataSource{}
+)
+
+type mediaTypeDataSource struct {
+	client *zabbix.Client
+}
+
+type mediaTypeDataSourceModel struct {
+	ID      types.String `tfsdk:"id"`
+	Name    types.String `tfsdk:"name"`
+	Type    types.String `tfsdk:"type"`
+	Enabled types.Bool   `tfsdk:"enabled"`
+}
+
+func NewMediaTypeDataSource() datasource.DataSource {
+	return &mediaTypeDataSource{}
+}
+
+func (d *mediaTypeDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_media_type"
+}
+
+func (d *mediaTypeDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Look up a Zabbix media type by name (e.g. built-in \"Email\"), typically for `zabbix_user` media or action operations.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Media type ID (mediatypeid).",
+			},
+			"name": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Exact name of the media type.",
+			},
+			"type": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Kind of media type: `email`, `script`, `sms` or `webhook`.",
+			},
+			"enabled": schema.BoolAttribute{
+				Computed:            true,
+				MarkdownDescription: "Whether the media type is enabled.",
+			},
+		},
+	}
+}
+
+func (d *mediaTypeDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	providerData, ok := req.ProviderData.(*providerData)
+	if !ok || providerData.Client == nil {
+		resp.Diagnostics.AddError("Invalid provider", "Zabbix client unavailable.")
+		return
+	}
+	d.client = providerData.Client
+}
+
+func (d *mediaTypeDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config mediaTypeDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	mt, err := d.client.MediaTypeGetByName(ctx, config.Name.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("mediatype.get error", err.Error())
+		return
+	}
+
+	config.ID = types.StringValue(mt.MediaTypeID)
+	config.Type = types.StringValue(codeToName(mediaTypeKinds, mt.Type, "email"))
+	config.Enabled = types.BoolValue(zabbix.StatusToEnabled(mt.Status))
+	resp.Diagnostics.Append(resp.State.Set(ctx, &config)...)
+}
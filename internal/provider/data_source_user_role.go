@@ -0,0 +1,90 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/rushiii/terraform-provider-zabbix/internal/zabbix"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ datasource.DataSource              = &userRoleDataSource{}
+	_ datasource.DataSourceWithConfigure = &userRoleDataSource{}
+)
+
+type userRoleDataSource struct {
+	client *zabbix.Client
+}
+
+type userRoleDataSourceModel struct {
+	ID       types.String `tfsdk:"id"`
+	Name     types.String `tfsdk:"name"`
+	Type     types.String `tfsdk:"type"`
+	ReadOnly types.Bool   `tfsdk:"readonly"`
+}
+
+func NewUserRoleDataSource() datasource.DataSource {
+	return &userRoleDataSource{}
+}
+
+func (d *userRoleDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_user_role"
+}
+
+func (d *userRoleDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Look up a Zabbix user role by name (e.g. built-in \"Admin role\"), typically for `zabbix_user.role_id`.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Role ID (roleid).",
+			},
+			"name": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Exact name of the role.",
+			},
+			"type": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "User type granted by the role: `user`, `admin` or `super_admin`.",
+			},
+			"readonly": schema.BoolAttribute{
+				Computed:            true,
+				MarkdownDescription: "Whether the role is a built-in read-only role.",
+			},
+		},
+	}
+}
+
+func (d *userRoleDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	providerData, ok := req.ProviderData.(*providerData)
+	if !ok || providerData.Client == nil {
+		resp.Diagnostics.AddError("Invalid provider", "Zabbix client unavailable.")
+		return
+	}
+	d.client = providerData.Client
+}
+
+func (d *userRoleDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config userRoleDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	role, err := d.client.RoleGetByName(ctx, config.Name.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("role.get error", err.Error())
+		return
+	}
+
+	config.ID = types.StringValue(role.RoleID)
+	config.Type = types.StringValue(userRoleTypeName(role.Type))
+	config.ReadOnly = types.BoolValue(role.ReadOnly == "1")
+	resp.Diagnostics.Append(resp.State.Set(ctx, &config)...)
+}
@@ -0,0 +1,28 @@
+package provider
+
+import "strconv"
+
+// codeToName maps a Zabbix numeric code (as string) to its schema name in names, or fallback.
+func codeToName(names []string, code, fallback string) string {
+	i, err := strconv.Atoi(code)
+	if err != nil || i < 0 || i >= len(names) || names[i] == "" {
+		return fallback
+	}
+	return names[i]
+}
+
+// nameToCode maps a schema name back to its Zabbix numeric code (as string); "0" if unknown.
+func nameToCode(names []string, name string) string {
+	for i, n := range names {
+		if n != "" && n == name {
+			return strconv.Itoa(i)
+		}
+	}
+	return "0"
+}
+
+// atoi64 parses a Zabbix integer string; 0 if invalid.
+func atoi64(value string) int64 {
+	n, _ := strconv.ParseInt(value, 10, 64)
+	return n
+}
@@ -170,6 +170,8 @@ func buildAuth(cfg providerModel) (zabbix.Auth, diag.Diagnostics) {
 func (p *zabbixProvider) DataSources(_ context.Context) []func() datasource.DataSource {
 	return []func() datasource.DataSource{
 		NewUserGroupDataSource,
+		NewUserRoleDataSource,
+		NewMediaTypeDataSource,
 	}
 }
 
@@ -180,8 +182,22 @@ func (p *zabbixProvider) Resources(_ context.Context) []func() resource.Resource
 		NewTemplateResource,
 		NewTriggerResource,
 		NewItemResource,
+		NewValueMapResource,
+		NewGraphResource,
+		NewDiscoveryRuleResource,
+		NewItemPrototypeResource,
+		NewTriggerPrototypeResource,
+		NewGraphPrototypeResource,
+		NewHostPrototypeResource,
+		NewDashboardResource,
+		NewTemplateDashboardResource,
+		NewMaintenanceResource,
+		NewProxyResource,
+		NewProxyGroupResource,
 		NewActionResource,
 		NewUserGroupResource,
 		NewUserResource,
+		NewUserRoleResource,
+		NewMediaTypeResource,
 	}
 }
@@ -2,24 +2,31 @@ package provider
 
 import (
 	"context"
+	"regexp"
+	"slices"
+	"strconv"
 	"strings"
 
 	"github.com/rushiii/terraform-provider-zabbix/internal/zabbix"
 
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
 var (
-	_ resource.Resource                = &actionResource{}
-	_ resource.ResourceWithConfigure   = &actionResource{}
-	_ resource.ResourceWithImportState = &actionResource{}
+	_ resource.Resource                   = &actionResource{}
+	_ resource.ResourceWithConfigure      = &actionResource{}
+	_ resource.ResourceWithImportState    = &actionResource{}
+	_ resource.ResourceWithValidateConfig = &actionResource{}
 )
 
 type actionResource struct {
@@ -27,16 +34,201 @@ type actionResource struct {
 }
 
 type actionResourceModel struct {
-	ID              types.String `tfsdk:"id"`
-	Name            types.String `tfsdk:"name"`
-	UserGroupIDs    types.Set    `tfsdk:"user_group_ids"`
-	UserIDs         types.Set    `tfsdk:"user_ids"`
-	HostGroupIDs    types.Set    `tfsdk:"host_group_ids"`
-	TriggerNameLike types.Set    `tfsdk:"trigger_name_like"`
-	Subject         types.String `tfsdk:"subject"`
-	Message         types.String `tfsdk:"message"`
-	Enabled         types.Bool   `tfsdk:"enabled"`
-	EscPeriod       types.String `tfsdk:"esc_period"`
+	ID                 types.String                   `tfsdk:"id"`
+	Name               types.String                   `tfsdk:"name"`
+	EventSource        types.String                   `tfsdk:"event_source"`
+	UserGroupIDs       types.Set                      `tfsdk:"user_group_ids"`
+	UserIDs            types.Set                      `tfsdk:"user_ids"`
+	HostGroupIDs       types.Set                      `tfsdk:"host_group_ids"`
+	TriggerNameLike    types.Set                      `tfsdk:"trigger_name_like"`
+	Subject            types.String                   `tfsdk:"subject"`
+	Message            types.String                   `tfsdk:"message"`
+	Enabled            types.Bool                     `tfsdk:"enabled"`
+	EscPeriod          types.String                   `tfsdk:"esc_period"`
+	EvalType           types.String                   `tfsdk:"evaltype"`
+	Formula            types.String                   `tfsdk:"formula"`
+	Conditions         []actionConditionModel         `tfsdk:"condition"`
+	Operations         []actionOperationModel         `tfsdk:"operation"`
+	RecoveryOperations []actionRecoveryOperationModel `tfsdk:"recovery_operation"`
+	UpdateOperations   []actionRecoveryOperationModel `tfsdk:"update_operation"`
+}
+
+type actionConditionModel struct {
+	Type     types.String `tfsdk:"type"`
+	Operator types.String `tfsdk:"operator"`
+	Value    types.String `tfsdk:"value"`
+	Value2   types.String `tfsdk:"value2"`
+}
+
+// actionConditionType is a condition type (conditiontype), the operators Zabbix accepts for it and the
+// event sources it applies to.
+type actionConditionType struct {
+	Name      string
+	Code      int
+	Operators []string
+	Sources   []string
+}
+
+var actionConditionTypes = []actionConditionType{
+	{"host_group", 0, []string{"equals", "not_equals"}, []string{"trigger", "internal"}},
+	{"host", 1, []string{"equals", "not_equals"}, []string{"trigger", "internal"}},
+	{"trigger", 2, []string{"equals", "not_equals"}, []string{"trigger"}},
+	{"event_name", 3, []string{"contains", "not_contains"}, []string{"trigger"}},
+	{"trigger_severity", 4, []string{"equals", "not_equals", "gte", "lte"}, []string{"trigger"}},
+	{"time_period", 6, []string{"in", "not_in"}, []string{"trigger"}},
+	{"host_ip", 7, []string{"equals", "not_equals"}, []string{"discovery"}},
+	{"service_type", 8, []string{"equals", "not_equals"}, []string{"discovery"}},
+	{"service_port", 9, []string{"equals", "not_equals"}, []string{"discovery"}},
+	{"discovery_status", 10, []string{"equals"}, []string{"discovery"}},
+	{"uptime_downtime", 11, []string{"gte", "lte"}, []string{"discovery"}},
+	{"received_value", 12, []string{"equals", "not_equals", "gte", "lte", "contains", "not_contains"}, []string{"discovery"}},
+	{"template", 13, []string{"equals", "not_equals"}, []string{"trigger", "internal"}},
+	{"problem_suppressed", 16, []string{"yes", "no"}, []string{"trigger"}},
+	{"discovery_rule", 18, []string{"equals", "not_equals"}, []string{"discovery"}},
+	{"discovery_check", 19, []string{"equals", "not_equals"}, []string{"discovery"}},
+	{"proxy", 20, []string{"equals", "not_equals"}, []string{"discovery", "autoregistration"}},
+	{"discovery_object", 21, []string{"equals"}, []string{"discovery"}},
+	{"host_name", 22, []string{"contains", "not_contains", "matches", "not_matches"}, []string{"autoregistration"}},
+	{"event_type", 23, []string{"equals"}, []string{"internal"}},
+	{"host_metadata", 24, []string{"contains", "not_contains", "matches", "not_matches"}, []string{"autoregistration"}},
+	{"event_tag", 25, []string{"equals", "not_equals", "contains", "not_contains"}, []string{"trigger", "internal", "service"}},
+	{"event_tag_value", 26, []string{"equals", "not_equals", "contains", "not_contains"}, []string{"trigger", "internal", "service"}},
+	{"service", 27, []string{"equals", "not_equals"}, []string{"service"}},
+	{"service_name", 28, []string{"equals", "not_equals", "contains", "not_contains"}, []string{"service"}},
+}
+
+// Condition operators, evaluation methods and operation types, indexed by Zabbix code. `notify_all` is
+// code 11 in recovery operations and 12 in update operations: see actionOperationTypeCode.
+var (
+	actionConditionOperators = []string{"equals", "not_equals", "contains", "not_contains", "in", "gte", "lte", "not_in", "matches", "not_matches", "yes", "no"}
+	actionEvalTypes          = []string{"and_or", "and", "or", "custom"}
+	actionOperationTypes     = []string{
+		"message", "command", "add_host", "remove_host", "add_to_host_group", "remove_from_host_group",
+		"link_template", "unlink_template", "enable_host", "disable_host", "set_inventory_mode",
+	}
+)
+
+// Operation types allowed per event source for operations, recovery operations and update operations.
+var (
+	actionHostOperationTypes = []string{
+		"message", "command", "add_host", "remove_host", "add_to_host_group", "remove_from_host_group",
+		"link_template", "unlink_template", "enable_host", "disable_host", "set_inventory_mode",
+	}
+	actionSourceOperationTypes = map[string][]string{
+		"trigger":          {"message", "command"},
+		"discovery":        actionHostOperationTypes,
+		"autoregistration": actionHostOperationTypes,
+		"internal":         {"message"},
+		"service":          {"message"},
+	}
+	actionSourceRecoveryOperationTypes = map[string][]string{
+		"trigger":  {"message", "command", "notify_all"},
+		"internal": {"message", "notify_all"},
+		"service":  {"message", "notify_all"},
+	}
+	actionSourceUpdateOperationTypes = map[string][]string{
+		"trigger": {"message", "command", "notify_all"},
+		"service": {"message", "notify_all"},
+	}
+)
+
+// actionSourceEscalates reports whether actions of the event source have escalations (esc_period, steps).
+func actionSourceEscalates(source string) bool {
+	return source == "trigger" || source == "internal" || source == "service"
+}
+
+func actionConditionTypeByName(name string) (actionConditionType, bool) {
+	for _, t := range actionConditionTypes {
+		if t.Name == name {
+			return t, true
+		}
+	}
+	return actionConditionType{}, false
+}
+
+func actionConditionTypeByCode(code string) (actionConditionType, bool) {
+	for _, t := range actionConditionTypes {
+		if strconv.Itoa(t.Code) == code {
+			return t, true
+		}
+	}
+	return actionConditionType{}, false
+}
+
+// actionFormulaLabel matches condition labels in a custom formula (operators are lowercase: and, or, not).
+var actionFormulaLabel = regexp.MustCompile(`\b[A-Z]+\b`)
+
+// actionFormulaID returns the custom formula label of the i-th condition: A..Z, then AA, AB, ...
+func actionFormulaID(i int) string {
+	id := ""
+	for i++; i > 0; i = (i - 1) / 26 {
+		id = string(rune('A'+(i-1)%26)) + id
+	}
+	return id
+}
+
+// actionOperationModel is an escalated operation ("operation" block).
+type actionOperationModel struct {
+	Type               types.String `tfsdk:"type"`
+	EscStepFrom        types.Int64  `tfsdk:"esc_step_from"`
+	EscStepTo          types.Int64  `tfsdk:"esc_step_to"`
+	EscPeriod          types.String `tfsdk:"esc_period"`
+	UserGroupIDs       types.Set    `tfsdk:"user_group_ids"`
+	UserIDs            types.Set    `tfsdk:"user_ids"`
+	MediaTypeID        types.String `tfsdk:"media_type_id"`
+	Subject            types.String `tfsdk:"subject"`
+	Message            types.String `tfsdk:"message"`
+	ScriptID           types.String `tfsdk:"script_id"`
+	TargetCurrentHost  types.Bool   `tfsdk:"target_current_host"`
+	TargetHostIDs      types.Set    `tfsdk:"target_host_ids"`
+	TargetHostGroupIDs types.Set    `tfsdk:"target_host_group_ids"`
+	HostGroupIDs       types.Set    `tfsdk:"host_group_ids"`
+	TemplateIDs        types.Set    `tfsdk:"template_ids"`
+	InventoryMode      types.String `tfsdk:"inventory_mode"`
+}
+
+// actionRecoveryOperationModel is a recovery or update operation: same as an operation, without escalation.
+type actionRecoveryOperationModel struct {
+	Type               types.String `tfsdk:"type"`
+	UserGroupIDs       types.Set    `tfsdk:"user_group_ids"`
+	UserIDs            types.Set    `tfsdk:"user_ids"`
+	MediaTypeID        types.String `tfsdk:"media_type_id"`
+	Subject            types.String `tfsdk:"subject"`
+	Message            types.String `tfsdk:"message"`
+	ScriptID           types.String `tfsdk:"script_id"`
+	TargetCurrentHost  types.Bool   `tfsdk:"target_current_host"`
+	TargetHostIDs      types.Set    `tfsdk:"target_host_ids"`
+	TargetHostGroupIDs types.Set    `tfsdk:"target_host_group_ids"`
+}
+
+func (m actionRecoveryOperationModel) operation() actionOperationModel {
+	return actionOperationModel{
+		Type:               m.Type,
+		UserGroupIDs:       m.UserGroupIDs,
+		UserIDs:            m.UserIDs,
+		MediaTypeID:        m.MediaTypeID,
+		Subject:            m.Subject,
+		Message:            m.Message,
+		ScriptID:           m.ScriptID,
+		TargetCurrentHost:  m.TargetCurrentHost,
+		TargetHostIDs:      m.TargetHostIDs,
+		TargetHostGroupIDs: m.TargetHostGroupIDs,
+	}
+}
+
+func (m actionOperationModel) recoveryOperation() actionRecoveryOperationModel {
+	return actionRecoveryOperationModel{
+		Type:               m.Type,
+		UserGroupIDs:       m.UserGroupIDs,
+		UserIDs:            m.UserIDs,
+		MediaTypeID:        m.MediaTypeID,
+		Subject:            m.Subject,
+		Message:            m.Message,
+		ScriptID:           m.ScriptID,
+		TargetCurrentHost:  m.TargetCurrentHost,
+		TargetHostIDs:      m.TargetHostIDs,
+		TargetHostGroupIDs: m.TargetHostGroupIDs,
+	}
 }
 
 func NewActionResource() resource.Resource {
@@ -47,9 +239,105 @@ func (r *actionResource) Metadata(_ context.Context, req resource.MetadataReques
 	resp.TypeName = req.ProviderTypeName + "_action"
 }
 
+// actionOperationAttributes returns the attributes of an operation block; primary (the "operation" block) adds
+// escalation steps and the host operation fields.
+func actionOperationAttributes(operationTypes []string, primary bool) map[string]schema.Attribute {
+	attrs := map[string]schema.Attribute{
+		"type": schema.StringAttribute{
+			Optional:            true,
+			Computed:            true,
+			Default:             stringdefault.StaticString("message"),
+			MarkdownDescription: "Operation type: `" + strings.Join(operationTypes, "`, `") + "`.",
+			Validators: []validator.String{
+				stringOneOf(operationTypes...),
+			},
+		},
+		"user_group_ids": schema.SetAttribute{
+			Optional:            true,
+			ElementType:         types.StringType,
+			MarkdownDescription: "`message`: IDs of user groups to notify.",
+		},
+		"user_ids": schema.SetAttribute{
+			Optional:            true,
+			ElementType:         types.StringType,
+			MarkdownDescription: "`message`: IDs of users to notify.",
+		},
+		"media_type_id": schema.StringAttribute{
+			Optional:            true,
+			MarkdownDescription: "`message`: send only through this media type. All media types if unset.",
+		},
+		"subject": schema.StringAttribute{
+			Optional:            true,
+			MarkdownDescription: "`message`: custom subject. If neither subject nor message is set, the media type message template is used.",
+		},
+		"message": schema.StringAttribute{
+			Optional:            true,
+			MarkdownDescription: "`message`: custom message body. Supports Zabbix macros.",
+		},
+		"script_id": schema.StringAttribute{
+			Optional:            true,
+			MarkdownDescription: "`command`: ID of the global script to run.",
+		},
+		"target_current_host": schema.BoolAttribute{
+			Optional:            true,
+			Computed:            true,
+			Default:             booldefault.StaticBool(false),
+			MarkdownDescription: "`command`: run the script on the host of the event.",
+		},
+		"target_host_ids": schema.SetAttribute{
+			Optional:            true,
+			ElementType:         types.StringType,
+			MarkdownDescription: "`command`: IDs of hosts to run the script on.",
+		},
+		"target_host_group_ids": schema.SetAttribute{
+			Optional:            true,
+			ElementType:         types.StringType,
+			MarkdownDescription: "`command`: IDs of host groups to run the script on.",
+		},
+	}
+	if primary {
+		attrs["esc_step_from"] = schema.Int64Attribute{
+			Optional:            true,
+			Computed:            true,
+			Default:             int64default.StaticInt64(1),
+			MarkdownDescription: "First escalation step of the operation.",
+		}
+		attrs["esc_step_to"] = schema.Int64Attribute{
+			Optional:            true,
+			Computed:            true,
+			Default:             int64default.StaticInt64(1),
+			MarkdownDescription: "Last escalation step of the operation (0 = infinitely).",
+		}
+		attrs["esc_period"] = schema.StringAttribute{
+			Optional:            true,
+			Computed:            true,
+			Default:             stringdefault.StaticString("0"),
+			MarkdownDescription: "Duration of each step of the operation (\"0\" = action `esc_period`).",
+		}
+		attrs["host_group_ids"] = schema.SetAttribute{
+			Optional:            true,
+			ElementType:         types.StringType,
+			MarkdownDescription: "`add_to_host_group`/`remove_from_host_group`: host group IDs.",
+		}
+		attrs["template_ids"] = schema.SetAttribute{
+			Optional:            true,
+			ElementType:         types.StringType,
+			MarkdownDescription: "`link_template`/`unlink_template`: template IDs.",
+		}
+		attrs["inventory_mode"] = schema.StringAttribute{
+			Optional:            true,
+			MarkdownDescription: "`set_inventory_mode`: `manual` or `automatic`.",
+			Validators: []validator.String{
+				stringOneOf("manual", "automatic"),
+			},
+		}
+	}
+	return attrs
+}
+
 func (r *actionResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
-		MarkdownDescription: "Zabbix trigger action: send notifications (e.g. email) when a trigger fires (problem).",
+		MarkdownDescription: "Zabbix action: send notifications (e.g. email) or run remote commands when a trigger fires (problem), recovers or is updated, and add or configure hosts on discovery and autoregistration.",
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
 				Computed: true,
@@ -61,15 +349,27 @@ func (r *actionResource) Schema(_ context.Context, _ resource.SchemaRequest, res
 				Required:            true,
 				MarkdownDescription: "Action name (e.g. \"Envoyer mail en cas de problème\").",
 			},
+			"event_source": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString("trigger"),
+				MarkdownDescription: "Events handled by the action: `trigger`, `discovery`, `autoregistration`, `internal` or `service`. Changing it recreates the action.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringOneOf(eventSourceNames...),
+				},
+			},
 			"user_group_ids": schema.SetAttribute{
-				Required:            true,
+				Optional:            true,
 				ElementType:         types.StringType,
-				MarkdownDescription: "IDs of user groups to notify (e.g. Zabbix administrators). Use data source or variable.",
+				MarkdownDescription: "IDs of user groups to notify (e.g. Zabbix administrators). Shorthand for a single `operation` block; cannot be combined with `operation` blocks.",
 			},
 			"user_ids": schema.SetAttribute{
 				Optional:            true,
 				ElementType:         types.StringType,
-				MarkdownDescription: "Optional: IDs of specific users to notify in addition to user groups (e.g. fst-audiovisuel).",
+				MarkdownDescription: "Optional: IDs of specific users to notify in addition to user groups (e.g. fst-audiovisuel). Shorthand, like `user_group_ids`.",
 			},
 			"host_group_ids": schema.SetAttribute{
 				Optional:            true,
@@ -82,12 +382,12 @@ func (r *actionResource) Schema(_ context.Context, _ resource.SchemaRequest, res
 				MarkdownDescription: "If set, action runs only when trigger name (description) contains any of these strings (e.g. [\"Lampe\", \"Laser\"] for Videoprojecteur Lampe/Laser).",
 			},
 			"subject": schema.StringAttribute{
-				Required:            true,
-				MarkdownDescription: "Email subject. Supports macros: {TRIGGER.NAME}, {HOST.NAME}, {EVENT.STATUS}, etc.",
+				Optional:            true,
+				MarkdownDescription: "Email subject of the `user_group_ids`/`user_ids` shorthand. Supports macros: {TRIGGER.NAME}, {HOST.NAME}, {EVENT.STATUS}, etc. If neither subject nor message is set, the media type message template is used.",
 			},
 			"message": schema.StringAttribute{
-				Required:            true,
-				MarkdownDescription: "Email body. Supports Zabbix macros.",
+				Optional:            true,
+				MarkdownDescription: "Email body of the `user_group_ids`/`user_ids` shorthand. Supports Zabbix macros.",
 			},
 			"enabled": schema.BoolAttribute{
 				Optional:            true,
@@ -99,7 +399,75 @@ func (r *actionResource) Schema(_ context.Context, _ resource.SchemaRequest, res
 				Optional:            true,
 				Computed:            true,
 				Default:             stringdefault.StaticString("1h"),
-				MarkdownDescription: "Minimum interval between notifications (e.g. \"1h\", \"60s\").",
+				MarkdownDescription: "Default duration of an escalation step (e.g. \"1h\", \"60s\").",
+			},
+			"evaltype": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString("and_or"),
+				MarkdownDescription: "How conditions are combined: `and_or` (AND between condition types, OR within a type), `and`, `or` or `custom` (`formula`).",
+				Validators: []validator.String{
+					stringOneOf(actionEvalTypes...),
+				},
+			},
+			"formula": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Custom condition formula with `evaltype = \"custom\"`, e.g. `A and (B or C)`.",
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"condition": schema.ListNestedBlock{
+				MarkdownDescription: "Condition the event must match. With `evaltype = \"custom\"`, conditions are labelled A, B, C, ... in block order.",
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"type": schema.StringAttribute{
+							Required:            true,
+							MarkdownDescription: "Condition type, e.g. `host_group`, `host`, `template`, `trigger`, `event_name`, `trigger_severity`, `time_period`, `problem_suppressed`, `event_tag`, `event_tag_value`.",
+							Validators: []validator.String{
+								stringOneOf(actionConditionTypeNames()...),
+							},
+						},
+						"operator": schema.StringAttribute{
+							Optional:            true,
+							Computed:            true,
+							Default:             stringdefault.StaticString("equals"),
+							MarkdownDescription: "`equals`, `not_equals`, `contains`, `not_contains`, `in`, `not_in`, `gte`, `lte`, `matches`, `not_matches`, `yes` or `no`.",
+							Validators: []validator.String{
+								stringOneOf(actionConditionOperators...),
+							},
+						},
+						"value": schema.StringAttribute{
+							Optional:            true,
+							Computed:            true,
+							Default:             stringdefault.StaticString(""),
+							MarkdownDescription: "Value to compare with (ID, severity 0-5, time period, tag, ...).",
+						},
+						"value2": schema.StringAttribute{
+							Optional:            true,
+							Computed:            true,
+							Default:             stringdefault.StaticString(""),
+							MarkdownDescription: "`event_tag_value`: tag name (`value` is the tag value).",
+						},
+					},
+				},
+			},
+			"operation": schema.SetNestedBlock{
+				MarkdownDescription: "Operation run when a problem starts (at the given escalation steps) or a host is discovered or registered.",
+				NestedObject: schema.NestedBlockObject{
+					Attributes: actionOperationAttributes(actionHostOperationTypes, true),
+				},
+			},
+			"recovery_operation": schema.SetNestedBlock{
+				MarkdownDescription: "Operation run when the problem is resolved.",
+				NestedObject: schema.NestedBlockObject{
+					Attributes: actionOperationAttributes([]string{"message", "command", "notify_all"}, false),
+				},
+			},
+			"update_operation": schema.SetNestedBlock{
+				MarkdownDescription: "Operation run when the problem is updated (acknowledged, commented, ...).",
+				NestedObject: schema.NestedBlockObject{
+					Attributes: actionOperationAttributes([]string{"message", "command", "notify_all"}, false),
+				},
 			},
 		},
 	}
@@ -117,6 +485,182 @@ func (r *actionResource) Configure(_ context.Context, req resource.ConfigureRequ
 	r.client = providerData.Client
 }
 
+func (r *actionResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config actionResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if (!config.Subject.IsNull() || !config.Message.IsNull()) && config.UserGroupIDs.IsNull() && config.UserIDs.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("subject"),
+			"Invalid attribute combination",
+			"`subject` and `message` apply to the `user_group_ids`/`user_ids` shorthand; set them on `operation` blocks otherwise.",
+		)
+	}
+	if (!config.UserGroupIDs.IsNull() || !config.UserIDs.IsNull()) && len(config.Operations) > 0 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("user_group_ids"),
+			"Conflicting attributes",
+			"`user_group_ids`/`user_ids` are a shorthand for a single operation and cannot be combined with `operation` blocks.",
+		)
+	}
+	validateActionConditions(config, &resp.Diagnostics)
+	for _, op := range config.Operations {
+		validateActionOperation(path.Root("operation"), op, &resp.Diagnostics)
+	}
+	for _, op := range config.RecoveryOperations {
+		validateActionOperation(path.Root("recovery_operation"), op.operation(), &resp.Diagnostics)
+	}
+	for _, op := range config.UpdateOperations {
+		validateActionOperation(path.Root("update_operation"), op.operation(), &resp.Diagnostics)
+	}
+
+	if config.EventSource.IsUnknown() {
+		return
+	}
+	source := config.EventSource.ValueString()
+	if source == "" {
+		source = "trigger"
+	}
+	validateActionOperationTypes(path.Root("operation"), source, actionSourceOperationTypes[source], config.Operations, &resp.Diagnostics)
+	recovery := make([]actionOperationModel, 0, len(config.RecoveryOperations))
+	for _, op := range config.RecoveryOperations {
+		recovery = append(recovery, op.operation())
+	}
+	validateActionOperationTypes(path.Root("recovery_operation"), source, actionSourceRecoveryOperationTypes[source], recovery, &resp.Diagnostics)
+	update := make([]actionOperationModel, 0, len(config.UpdateOperations))
+	for _, op := range config.UpdateOperations {
+		update = append(update, op.operation())
+	}
+	validateActionOperationTypes(path.Root("update_operation"), source, actionSourceUpdateOperationTypes[source], update, &resp.Diagnostics)
+	if source != "trigger" && !config.TriggerNameLike.IsNull() {
+		resp.Diagnostics.AddAttributeError(path.Root("trigger_name_like"), "Invalid attribute combination", "`trigger_name_like` only applies to `trigger` actions.")
+	}
+	if source != "trigger" && source != "internal" && !config.HostGroupIDs.IsNull() {
+		resp.Diagnostics.AddAttributeError(path.Root("host_group_ids"), "Invalid attribute combination", "`host_group_ids` only applies to `trigger` and `internal` actions.")
+	}
+	for _, c := range config.Conditions {
+		t, ok := actionConditionTypeByName(c.Type.ValueString())
+		if ok && !slices.Contains(t.Sources, source) {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("condition"),
+				"Invalid condition type",
+				"Condition type `"+t.Name+"` does not apply to `"+source+"` actions.",
+			)
+		}
+	}
+}
+
+// validateActionOperationTypes checks that operations are of a type the event source supports.
+func validateActionOperationTypes(p path.Path, source string, allowed []string, ops []actionOperationModel, diags *diag.Diagnostics) {
+	for _, op := range ops {
+		if op.Type.IsUnknown() {
+			continue
+		}
+		opType := op.Type.ValueString()
+		if opType == "" {
+			opType = "message"
+		}
+		if !slices.Contains(allowed, opType) {
+			if len(allowed) == 0 {
+				diags.AddAttributeError(p, "Invalid operation", "`"+source+"` actions do not support these operations.")
+				return
+			}
+			diags.AddAttributeError(p, "Invalid operation", "`"+source+"` actions support operation types: `"+strings.Join(allowed, "`, `")+"`; got `"+opType+"`.")
+		}
+	}
+}
+
+// validateActionConditions checks operator/type combinations and the custom formula labels.
+func validateActionConditions(config actionResourceModel, diags *diag.Diagnostics) {
+	for _, c := range config.Conditions {
+		if c.Type.IsUnknown() || c.Operator.IsUnknown() {
+			continue
+		}
+		t, ok := actionConditionTypeByName(c.Type.ValueString())
+		if !ok {
+			continue
+		}
+		operator := c.Operator.ValueString()
+		if operator == "" {
+			operator = "equals"
+		}
+		if !slices.Contains(t.Operators, operator) {
+			diags.AddAttributeError(
+				path.Root("condition"),
+				"Invalid condition operator",
+				"Condition type `"+t.Name+"` supports operators: `"+strings.Join(t.Operators, "`, `")+"`; got `"+operator+"`.",
+			)
+		}
+	}
+
+	if config.EvalType.IsUnknown() || config.Formula.IsUnknown() {
+		return
+	}
+	if config.EvalType.ValueString() != "custom" {
+		if !config.Formula.IsNull() {
+			diags.AddAttributeError(path.Root("formula"), "Invalid attribute combination", "`formula` requires `evaltype = \"custom\"`.")
+		}
+		return
+	}
+	if config.Formula.IsNull() {
+		diags.AddAttributeError(path.Root("formula"), "Missing attribute", "`evaltype = \"custom\"` requires `formula`.")
+		return
+	}
+	if !config.HostGroupIDs.IsNull() || !config.TriggerNameLike.IsNull() {
+		diags.AddAttributeError(path.Root("evaltype"), "Invalid attribute combination", "`host_group_ids` and `trigger_name_like` cannot be used with a custom formula; use `condition` blocks.")
+	}
+	labels := make(map[string]bool, len(config.Conditions))
+	for i := range config.Conditions {
+		labels[actionFormulaID(i)] = false
+	}
+	for _, token := range actionFormulaLabel.FindAllString(config.Formula.ValueString(), -1) {
+		if _, ok := labels[token]; !ok {
+			diags.AddAttributeError(path.Root("formula"), "Invalid formula", "Label "+token+" does not match any `condition` block.")
+			continue
+		}
+		labels[token] = true
+	}
+	for i := range config.Conditions {
+		if !labels[actionFormulaID(i)] {
+			diags.AddAttributeError(path.Root("formula"), "Invalid formula", "Condition "+actionFormulaID(i)+" is not used in the formula.")
+		}
+	}
+}
+
+// validateActionOperation checks that an operation has the fields its type requires. Unknown values are skipped.
+func validateActionOperation(p path.Path, op actionOperationModel, diags *diag.Diagnostics) {
+	if op.Type.IsUnknown() {
+		return
+	}
+	switch op.Type.ValueString() {
+	case "", "message":
+		if op.UserGroupIDs.IsNull() && op.UserIDs.IsNull() {
+			diags.AddAttributeError(p, "Invalid operation", "A `message` operation requires `user_group_ids` or `user_ids`.")
+		}
+	case "command":
+		if op.ScriptID.IsNull() {
+			diags.AddAttributeError(p, "Invalid operation", "A `command` operation requires `script_id`.")
+		}
+		if !op.TargetCurrentHost.ValueBool() && !op.TargetCurrentHost.IsUnknown() && op.TargetHostIDs.IsNull() && op.TargetHostGroupIDs.IsNull() {
+			diags.AddAttributeError(p, "Invalid operation", "A `command` operation requires `target_current_host`, `target_host_ids` or `target_host_group_ids`.")
+		}
+	case "add_to_host_group", "remove_from_host_group":
+		if op.HostGroupIDs.IsNull() {
+			diags.AddAttributeError(p, "Invalid operation", "A `"+op.Type.ValueString()+"` operation requires `host_group_ids`.")
+		}
+	case "link_template", "unlink_template":
+		if op.TemplateIDs.IsNull() {
+			diags.AddAttributeError(p, "Invalid operation", "A `"+op.Type.ValueString()+"` operation requires `template_ids`.")
+		}
+	case "set_inventory_mode":
+		if op.InventoryMode.IsNull() {
+			diags.AddAttributeError(p, "Invalid operation", "A `set_inventory_mode` operation requires `inventory_mode`.")
+		}
+	}
+}
+
 func (r *actionResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	var plan actionResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
@@ -124,26 +668,12 @@ func (r *actionResource) Create(ctx context.Context, req resource.CreateRequest,
 		return
 	}
 
-	groupIDs, d := setToStrings(ctx, plan.UserGroupIDs)
+	actionReq, d := expandAction(ctx, plan)
 	resp.Diagnostics.Append(d...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
-	userIDs, _ := setToStringsOptional(ctx, plan.UserIDs)
-	hostGroupIDs, _ := setToStringsOptional(ctx, plan.HostGroupIDs)
-	triggerNameLike, _ := setToStringsOptional(ctx, plan.TriggerNameLike)
-
-	id, err := r.client.ActionCreate(ctx, zabbix.ActionCreateRequest{
-		Name:            plan.Name.ValueString(),
-		UserGroupIDs:    groupIDs,
-		UserIDs:         userIDs,
-		HostGroupIDs:    hostGroupIDs,
-		TriggerNameLike: triggerNameLike,
-		Subject:         plan.Subject.ValueString(),
-		Message:         plan.Message.ValueString(),
-		Enabled:         plan.Enabled.ValueBool(),
-		EscPeriod:       plan.EscPeriod.ValueString(),
-	})
+	id, err := r.client.ActionCreate(ctx, actionReq)
 	if err != nil {
 		resp.Diagnostics.AddError("action.create error", err.Error())
 		return
@@ -171,70 +701,133 @@ func (r *actionResource) Read(ctx context.Context, req resource.ReadRequest, res
 	}
 
 	state.Name = types.StringValue(action.Name)
-	subject := action.DefShortData
-	if subject == "" {
-		subject = "Zabbix: {TRIGGER.STATUS} - {HOST.NAME}: {TRIGGER.NAME}"
-	}
-	state.Subject = types.StringValue(strings.TrimSpace(subject))
-	message := action.DefLongData
-	if message == "" {
-		message = "Trigger: {TRIGGER.NAME}\nHost: {HOST.NAME}\nSeverity: {TRIGGER.SEVERITY}\nStatus: {TRIGGER.STATUS}\nTime: {EVENT.DATE} {EVENT.TIME}\nItem value: {ITEM.VALUE}\n"
-	}
-	state.Message = types.StringValue(normalizeActionMessage(message))
+	state.EventSource = types.StringValue(codeToName(eventSourceNames, action.EventSource, "trigger"))
 	state.Enabled = types.BoolValue(action.Status == "0")
 	state.EscPeriod = types.StringValue(action.EscPeriod)
 
-	groupIDs := make([]string, 0)
-	userIDs := make([]string, 0)
-	for _, op := range action.Operations {
-		for _, g := range op.OpmessageGrp {
-			if g.UsrgrpID != "" {
-				groupIDs = append(groupIDs, g.UsrgrpID)
+	// The user_group_ids/user_ids shorthand stays in use as long as it is in state; otherwise operations are
+	// read into blocks.
+	state.Operations = make([]actionOperationModel, 0, len(action.Operations))
+	if !state.UserGroupIDs.IsNull() || !state.UserIDs.IsNull() {
+		groupIDs := make([]string, 0)
+		userIDs := make([]string, 0)
+		for _, op := range action.Operations {
+			for _, g := range op.OpmessageGrp {
+				if g.UsrgrpID != "" {
+					groupIDs = append(groupIDs, g.UsrgrpID)
+				}
+			}
+			for _, u := range op.OpmessageUsr {
+				if u.UserID != "" {
+					userIDs = append(userIDs, u.UserID)
+				}
 			}
 		}
-		for _, u := range op.OpmessageUsr {
-			if u.UserID != "" {
-				userIDs = append(userIDs, u.UserID)
+		state.UserGroupIDs = stringsToSetOrNull(ctx, groupIDs)
+		state.UserIDs = stringsToSetOrNull(ctx, userIDs)
+
+		// subject/message come from the custom message of the shorthand operation.
+		subject := types.StringNull()
+		message := types.StringNull()
+		for _, op := range action.Operations {
+			if op.Opmessage == nil || op.OperationType != strconv.Itoa(zabbix.ActionOperationMessage) {
+				continue
+			}
+			if op.Opmessage.DefaultMsg == "0" {
+				subject = types.StringValue(op.Opmessage.Subject)
+				message = types.StringValue(op.Opmessage.Message)
 			}
+			break
 		}
-	}
-	state.UserGroupIDs, _ = types.SetValueFrom(ctx, types.StringType, groupIDs)
-	if len(userIDs) > 0 {
-		state.UserIDs, _ = types.SetValueFrom(ctx, types.StringType, userIDs)
+		if subject.ValueString() == "" && state.Subject.IsNull() {
+			subject = types.StringNull()
+		}
+		// Keep the configured message when it only differs by whitespace (heredoc indentation, trailing newline).
+		if !message.IsNull() && !state.Message.IsNull() &&
+			normalizeActionMessage(message.ValueString()) == normalizeActionMessage(state.Message.ValueString()) {
+			message = state.Message
+		}
+		if message.ValueString() == "" && state.Message.IsNull() {
+			message = types.StringNull()
+		}
+		state.Subject = subject
+		state.Message = message
 	} else {
-		state.UserIDs = types.SetNull(types.StringType)
+		state.Subject = types.StringNull()
+		state.Message = types.StringNull()
+		for _, op := range action.Operations {
+			state.Operations = append(state.Operations, flattenActionOperation(ctx, op))
+		}
+	}
+	state.RecoveryOperations = make([]actionRecoveryOperationModel, 0, len(action.RecoveryOperations))
+	for _, op := range action.RecoveryOperations {
+		state.RecoveryOperations = append(state.RecoveryOperations, flattenActionOperation(ctx, op).recoveryOperation())
+	}
+	state.UpdateOperations = make([]actionRecoveryOperationModel, 0, len(action.UpdateOperations))
+	for _, op := range action.UpdateOperations {
+		state.UpdateOperations = append(state.UpdateOperations, flattenActionOperation(ctx, op).recoveryOperation())
 	}
 
-	// Rebuild host_group_ids and trigger_name_like from conditions (type 0 = host group, 2/3 = trigger name).
 	conds := action.Conditions
-	if action.Filter != nil && len(action.Filter.Conditions) > 0 {
-		conds = action.Filter.Conditions
+	evalType := action.EvalType
+	formula := ""
+	if action.Filter != nil {
+		if len(action.Filter.Conditions) > 0 {
+			conds = action.Filter.Conditions
+		}
+		evalType = action.Filter.EvalType
+		formula = action.Filter.Formula
+	}
+	state.EvalType = types.StringValue(codeToName(actionEvalTypes, evalType, "and_or"))
+	if evalType == strconv.Itoa(zabbix.ActionEvalTypeCustom) {
+		state.Formula = types.StringValue(formula)
+		conds = slices.Clone(conds)
+		slices.SortStableFunc(conds, func(a, b zabbix.ActionCondition) int {
+			if len(a.FormulaID) != len(b.FormulaID) {
+				return len(a.FormulaID) - len(b.FormulaID)
+			}
+			return strings.Compare(a.FormulaID, b.FormulaID)
+		})
+	} else {
+		state.Formula = types.StringNull()
 	}
+
+	// Host group and event name conditions go to host_group_ids/trigger_name_like while those shorthands
+	// are in state; every other condition is read into condition blocks.
+	useHostGroupIDs := !state.HostGroupIDs.IsNull()
+	useTriggerNameLike := !state.TriggerNameLike.IsNull()
 	hostGroupIDs := make([]string, 0)
 	triggerNameLike := make([]string, 0)
+	conditions := make([]actionConditionModel, 0, len(conds))
 	for _, c := range conds {
-		ct := string(c.ConditionType)
-		switch ct {
-		case "0":
-			if c.Value != "" {
-				hostGroupIDs = append(hostGroupIDs, c.Value)
-			}
-		case "2", "3":
-			if c.Value != "" {
-				triggerNameLike = append(triggerNameLike, c.Value)
-			}
+		switch {
+		case c.ConditionType == "3" && c.Operator == "2" && c.Value == "":
+			// An empty event name condition matches every event; nothing to track.
+			continue
+		case useHostGroupIDs && c.ConditionType == "0" && c.Operator == "0":
+			hostGroupIDs = append(hostGroupIDs, c.Value)
+			continue
+		case useTriggerNameLike && c.ConditionType == "3" && c.Operator == "2":
+			triggerNameLike = append(triggerNameLike, c.Value)
+			continue
 		}
+		t, ok := actionConditionTypeByCode(c.ConditionType)
+		if !ok {
+			continue
+		}
+		conditions = append(conditions, actionConditionModel{
+			Type:     types.StringValue(t.Name),
+			Operator: types.StringValue(codeToName(actionConditionOperators, c.Operator, "equals")),
+			Value:    types.StringValue(c.Value),
+			Value2:   types.StringValue(c.Value2),
+		})
 	}
-	if len(hostGroupIDs) > 0 {
-		state.HostGroupIDs, _ = types.SetValueFrom(ctx, types.StringType, hostGroupIDs)
-	} else {
-		state.HostGroupIDs = types.SetNull(types.StringType)
-	}
-	if len(triggerNameLike) > 0 {
-		state.TriggerNameLike, _ = types.SetValueFrom(ctx, types.StringType, triggerNameLike)
-	} else {
-		state.TriggerNameLike = types.SetNull(types.StringType)
+	if evalType != strconv.Itoa(zabbix.ActionEvalTypeCustom) {
+		conditions = orderActionConditions(conditions, state.Conditions)
 	}
+	state.Conditions = conditions
+	state.HostGroupIDs = stringsToSetOrNull(ctx, hostGroupIDs)
+	state.TriggerNameLike = stringsToSetOrNull(ctx, triggerNameLike)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 }
@@ -248,31 +841,18 @@ func (r *actionResource) Update(ctx context.Context, req resource.UpdateRequest,
 		return
 	}
 
-	groupIDs, d := setToStrings(ctx, plan.UserGroupIDs)
+	actionReq, d := expandAction(ctx, plan)
 	resp.Diagnostics.Append(d...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
-	userIDs, _ := setToStringsOptional(ctx, plan.UserIDs)
-	hostGroupIDs, _ := setToStringsOptional(ctx, plan.HostGroupIDs)
-	triggerNameLike, _ := setToStringsOptional(ctx, plan.TriggerNameLike)
-
-	err := r.client.ActionUpdate(ctx, state.ID.ValueString(), zabbix.ActionCreateRequest{
-		Name:            plan.Name.ValueString(),
-		UserGroupIDs:    groupIDs,
-		UserIDs:         userIDs,
-		HostGroupIDs:    hostGroupIDs,
-		TriggerNameLike: triggerNameLike,
-		Subject:         plan.Subject.ValueString(),
-		Message:         plan.Message.ValueString(),
-		Enabled:         plan.Enabled.ValueBool(),
-		EscPeriod:       plan.EscPeriod.ValueString(),
-	})
+	err := r.client.ActionUpdate(ctx, state.ID.ValueString(), actionReq)
 	if err != nil {
 		resp.Diagnostics.AddError("action.update error", err.Error())
 		return
 	}
 
+	plan.ID = state.ID
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
 }
 
@@ -292,6 +872,328 @@ func (r *actionResource) ImportState(ctx context.Context, req resource.ImportSta
 	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
 }
 
+// expandAction builds the action.create/update request from the plan.
+func expandAction(ctx context.Context, plan actionResourceModel) (zabbix.ActionCreateRequest, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	evalType, _ := strconv.Atoi(nameToCode(actionEvalTypes, plan.EvalType.ValueString()))
+	eventSource, _ := strconv.Atoi(nameToCode(eventSourceNames, plan.EventSource.ValueString()))
+	escalation := actionSourceEscalates(plan.EventSource.ValueString())
+	req := zabbix.ActionCreateRequest{
+		Name:        plan.Name.ValueString(),
+		EventSource: eventSource,
+		Enabled:     plan.Enabled.ValueBool(),
+		EscPeriod:   plan.EscPeriod.ValueString(),
+		EvalType:    evalType,
+	}
+
+	for i, c := range plan.Conditions {
+		t, _ := actionConditionTypeByName(c.Type.ValueString())
+		cond := zabbix.ActionCondition{
+			ConditionType: strconv.Itoa(t.Code),
+			Operator:      nameToCode(actionConditionOperators, c.Operator.ValueString()),
+			Value:         c.Value.ValueString(),
+			Value2:        c.Value2.ValueString(),
+		}
+		if evalType == zabbix.ActionEvalTypeCustom {
+			cond.FormulaID = actionFormulaID(i)
+		}
+		req.Conditions = append(req.Conditions, cond)
+	}
+	if evalType == zabbix.ActionEvalTypeCustom {
+		req.Formula = plan.Formula.ValueString()
+	}
+	// Shorthand conditions: host group (type 0) equals, event name (type 3) contains.
+	hostGroupIDs, d := setToStringsOptional(ctx, plan.HostGroupIDs)
+	diags.Append(d...)
+	for _, gid := range hostGroupIDs {
+		if gid != "" {
+			req.Conditions = append(req.Conditions, zabbix.ActionCondition{ConditionType: "0", Operator: "0", Value: gid})
+		}
+	}
+	triggerNameLike, d := setToStringsOptional(ctx, plan.TriggerNameLike)
+	diags.Append(d...)
+	for _, pat := range triggerNameLike {
+		if pat != "" {
+			req.Conditions = append(req.Conditions, zabbix.ActionCondition{ConditionType: "3", Operator: "2", Value: pat})
+		}
+	}
+
+	if !plan.UserGroupIDs.IsNull() || !plan.UserIDs.IsNull() {
+		groupIDs, d := setToStringsOptional(ctx, plan.UserGroupIDs)
+		diags.Append(d...)
+		userIDs, d := setToStringsOptional(ctx, plan.UserIDs)
+		diags.Append(d...)
+		op := zabbix.ActionOperation{
+			OperationType: strconv.Itoa(zabbix.ActionOperationMessage),
+			Opmessage: expandActionOpmessage(actionOperationModel{
+				Subject: plan.Subject,
+				Message: plan.Message,
+			}),
+		}
+		for _, gid := range groupIDs {
+			op.OpmessageGrp = append(op.OpmessageGrp, zabbix.ActionOpmessageGrp{UsrgrpID: gid})
+		}
+		for _, uid := range userIDs {
+			if uid != "" {
+				op.OpmessageUsr = append(op.OpmessageUsr, zabbix.ActionOpmessageUsr{UserID: uid})
+			}
+		}
+		req.Operations = append(req.Operations, op)
+	}
+	for _, op := range plan.Operations {
+		o, d := expandActionOperation(ctx, op, escalation, zabbix.ActionOperationNotifyAll)
+		diags.Append(d...)
+		req.Operations = append(req.Operations, o)
+	}
+	for _, op := range plan.RecoveryOperations {
+		o, d := expandActionOperation(ctx, op.operation(), false, zabbix.ActionOperationNotifyAll)
+		diags.Append(d...)
+		req.RecoveryOperations = append(req.RecoveryOperations, o)
+	}
+	for _, op := range plan.UpdateOperations {
+		o, d := expandActionOperation(ctx, op.operation(), false, zabbix.ActionOperationNotifyUpdate)
+		diags.Append(d...)
+		req.UpdateOperations = append(req.UpdateOperations, o)
+	}
+	return req, diags
+}
+
+// actionOperationTypeCode maps an operation type to its Zabbix code; notifyAll is the code of `notify_all`,
+// which depends on the kind of operation.
+func actionOperationTypeCode(name string, notifyAll int) string {
+	if name == "notify_all" {
+		return strconv.Itoa(notifyAll)
+	}
+	return nameToCode(actionOperationTypes, name)
+}
+
+// actionOperationTypeName maps a Zabbix operation type code back to its name.
+func actionOperationTypeName(code string) string {
+	switch code {
+	case strconv.Itoa(zabbix.ActionOperationNotifyAll), strconv.Itoa(zabbix.ActionOperationNotifyUpdate):
+		return "notify_all"
+	}
+	return codeToName(actionOperationTypes, code, "message")
+}
+
+// expandActionOperation converts an operation block; escalation fields are only sent for escalated operations.
+func expandActionOperation(ctx context.Context, op actionOperationModel, escalation bool, notifyAll int) (zabbix.ActionOperation, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	o := zabbix.ActionOperation{
+		OperationType: actionOperationTypeCode(op.Type.ValueString(), notifyAll),
+	}
+
+	if escalation {
+		o.EscStepFrom = strconv.FormatInt(op.EscStepFrom.ValueInt64(), 10)
+		o.EscStepTo = strconv.FormatInt(op.EscStepTo.ValueInt64(), 10)
+		o.EscPeriod = op.EscPeriod.ValueString()
+	}
+
+	switch op.Type.ValueString() {
+	case "command":
+		o.Opcommand = &zabbix.ActionOpcommand{ScriptID: op.ScriptID.ValueString()}
+		if op.TargetCurrentHost.ValueBool() {
+			o.OpcommandHst = append(o.OpcommandHst, zabbix.ActionOpcommandHst{HostID: "0"})
+		}
+		hostIDs, d := setToStringsOptional(ctx, op.TargetHostIDs)
+		diags.Append(d...)
+		for _, id := range hostIDs {
+			o.OpcommandHst = append(o.OpcommandHst, zabbix.ActionOpcommandHst{HostID: id})
+		}
+		groupIDs, d := setToStringsOptional(ctx, op.TargetHostGroupIDs)
+		diags.Append(d...)
+		for _, id := range groupIDs {
+			o.OpcommandGrp = append(o.OpcommandGrp, zabbix.ActionOpcommandGrp{GroupID: id})
+		}
+	case "notify_all":
+		o.Opmessage = expandActionOpmessage(op)
+	case "add_to_host_group", "remove_from_host_group":
+		groupIDs, d := setToStringsOptional(ctx, op.HostGroupIDs)
+		diags.Append(d...)
+		for _, id := range groupIDs {
+			o.Opgroup = append(o.Opgroup, zabbix.ActionOpgroup{GroupID: id})
+		}
+	case "link_template", "unlink_template":
+		templateIDs, d := setToStringsOptional(ctx, op.TemplateIDs)
+		diags.Append(d...)
+		for _, id := range templateIDs {
+			o.Optemplate = append(o.Optemplate, zabbix.ActionOptemplate{TemplateID: id})
+		}
+	case "set_inventory_mode":
+		mode := "0"
+		if op.InventoryMode.ValueString() == "automatic" {
+			mode = "1"
+		}
+		o.Opinventory = &zabbix.ActionOpinventory{InventoryMode: mode}
+	case "add_host", "remove_host", "enable_host", "disable_host":
+		// No operation details.
+	default:
+		o.Opmessage = expandActionOpmessage(op)
+		groupIDs, d := setToStringsOptional(ctx, op.UserGroupIDs)
+		diags.Append(d...)
+		for _, id := range groupIDs {
+			o.OpmessageGrp = append(o.OpmessageGrp, zabbix.ActionOpmessageGrp{UsrgrpID: id})
+		}
+		userIDs, d := setToStringsOptional(ctx, op.UserIDs)
+		diags.Append(d...)
+		for _, id := range userIDs {
+			o.OpmessageUsr = append(o.OpmessageUsr, zabbix.ActionOpmessageUsr{UserID: id})
+		}
+	}
+	return o, diags
+}
+
+// expandActionOpmessage uses the media type template unless a subject or message is set.
+func expandActionOpmessage(op actionOperationModel) *zabbix.ActionOpmessage {
+	msg := &zabbix.ActionOpmessage{
+		DefaultMsg:  "1",
+		MediaTypeID: nullableString(op.MediaTypeID),
+	}
+	if !op.Subject.IsNull() || !op.Message.IsNull() {
+		msg.DefaultMsg = "0"
+		msg.Subject = op.Subject.ValueString()
+		msg.Message = op.Message.ValueString()
+	}
+	return msg
+}
+
+func flattenActionOperation(ctx context.Context, o zabbix.ActionOperation) actionOperationModel {
+	op := actionOperationModel{
+		Type:               types.StringValue("message"),
+		EscStepFrom:        types.Int64Value(1),
+		EscStepTo:          types.Int64Value(1),
+		EscPeriod:          types.StringValue("0"),
+		UserGroupIDs:       types.SetNull(types.StringType),
+		UserIDs:            types.SetNull(types.StringType),
+		MediaTypeID:        types.StringNull(),
+		Subject:            types.StringNull(),
+		Message:            types.StringNull(),
+		ScriptID:           types.StringNull(),
+		TargetCurrentHost:  types.BoolValue(false),
+		TargetHostIDs:      types.SetNull(types.StringType),
+		TargetHostGroupIDs: types.SetNull(types.StringType),
+		HostGroupIDs:       types.SetNull(types.StringType),
+		TemplateIDs:        types.SetNull(types.StringType),
+		InventoryMode:      types.StringNull(),
+	}
+	if o.EscStepFrom != "" {
+		op.EscStepFrom = types.Int64Value(atoi64(o.EscStepFrom))
+	}
+	if o.EscStepTo != "" {
+		op.EscStepTo = types.Int64Value(atoi64(o.EscStepTo))
+	}
+	if o.EscPeriod != "" {
+		op.EscPeriod = types.StringValue(o.EscPeriod)
+	}
+
+	op.Type = types.StringValue(actionOperationTypeName(o.OperationType))
+	switch op.Type.ValueString() {
+	case "command":
+		if o.Opcommand != nil {
+			op.ScriptID = nullOrString(o.Opcommand.ScriptID)
+		}
+		hostIDs := make([]string, 0, len(o.OpcommandHst))
+		for _, h := range o.OpcommandHst {
+			if h.HostID == "0" {
+				op.TargetCurrentHost = types.BoolValue(true)
+				continue
+			}
+			hostIDs = append(hostIDs, h.HostID)
+		}
+		op.TargetHostIDs = stringsToSetOrNull(ctx, hostIDs)
+		groupIDs := make([]string, 0, len(o.OpcommandGrp))
+		for _, g := range o.OpcommandGrp {
+			groupIDs = append(groupIDs, g.GroupID)
+		}
+		op.TargetHostGroupIDs = stringsToSetOrNull(ctx, groupIDs)
+	case "notify_all":
+		flattenActionOpmessage(o.Opmessage, &op)
+	case "add_to_host_group", "remove_from_host_group":
+		groupIDs := make([]string, 0, len(o.Opgroup))
+		for _, g := range o.Opgroup {
+			groupIDs = append(groupIDs, g.GroupID)
+		}
+		op.HostGroupIDs = stringsToSetOrNull(ctx, groupIDs)
+	case "link_template", "unlink_template":
+		templateIDs := make([]string, 0, len(o.Optemplate))
+		for _, t := range o.Optemplate {
+			templateIDs = append(templateIDs, t.TemplateID)
+		}
+		op.TemplateIDs = stringsToSetOrNull(ctx, templateIDs)
+	case "set_inventory_mode":
+		op.InventoryMode = types.StringValue("manual")
+		if o.Opinventory != nil && o.Opinventory.InventoryMode == "1" {
+			op.InventoryMode = types.StringValue("automatic")
+		}
+	case "add_host", "remove_host", "enable_host", "disable_host":
+	default:
+		flattenActionOpmessage(o.Opmessage, &op)
+		groupIDs := make([]string, 0, len(o.OpmessageGrp))
+		for _, g := range o.OpmessageGrp {
+			groupIDs = append(groupIDs, g.UsrgrpID)
+		}
+		op.UserGroupIDs = stringsToSetOrNull(ctx, groupIDs)
+		userIDs := make([]string, 0, len(o.OpmessageUsr))
+		for _, u := range o.OpmessageUsr {
+			userIDs = append(userIDs, u.UserID)
+		}
+		op.UserIDs = stringsToSetOrNull(ctx, userIDs)
+	}
+	return op
+}
+
+func flattenActionOpmessage(msg *zabbix.ActionOpmessage, op *actionOperationModel) {
+	if msg == nil {
+		return
+	}
+	if msg.MediaTypeID != "" && msg.MediaTypeID != "0" {
+		op.MediaTypeID = types.StringValue(msg.MediaTypeID)
+	}
+	if msg.DefaultMsg == "0" {
+		op.Subject = nullOrString(msg.Subject)
+		op.Message = nullOrString(msg.Message)
+	}
+}
+
+// orderActionConditions sorts conditions read from Zabbix in the order of the previous state, new ones last.
+func orderActionConditions(conditions, previous []actionConditionModel) []actionConditionModel {
+	ordered := make([]actionConditionModel, 0, len(conditions))
+	used := make([]bool, len(conditions))
+	for _, p := range previous {
+		for i, c := range conditions {
+			if !used[i] && c.Type.Equal(p.Type) && c.Operator.Equal(p.Operator) && c.Value.Equal(p.Value) && c.Value2.Equal(p.Value2) {
+				used[i] = true
+				ordered = append(ordered, c)
+				break
+			}
+		}
+	}
+	for i, c := range conditions {
+		if !used[i] {
+			ordered = append(ordered, c)
+		}
+	}
+	return ordered
+}
+
+func actionConditionTypeNames() []string {
+	names := make([]string, 0, len(actionConditionTypes))
+	for _, t := range actionConditionTypes {
+		names = append(names, t.Name)
+	}
+	return names
+}
+
+// stringsToSetOrNull returns a set of the values, or a null set when there are none.
+func stringsToSetOrNull(ctx context.Context, values []string) types.Set {
+	if len(values) == 0 {
+		return types.SetNull(types.StringType)
+	}
+	set, _ := types.SetValueFrom(ctx, types.StringType, values)
+	return set
+}
+
 // normalizeActionMessage rend le message canonique pour éviter la dérive avec le heredoc Terraform :
 func normalizeActionMessage(s string) string {
 	s = strings.ReplaceAll(s, "\r\n", "\n")
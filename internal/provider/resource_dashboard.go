@@ -0,0 +1,664 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+
+	"github.com/rushiii/terraform-provider-zabbix/internal/zabbix"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ resource.Resource                   = &dashboardResource{}
+	_ resource.ResourceWithConfigure      = &dashboardResource{}
+	_ resource.ResourceWithImportState    = &dashboardResource{}
+	_ resource.ResourceWithValidateConfig = &dashboardResource{}
+)
+
+// dashboardFieldTypes are the widget field types, indexed by Zabbix code.
+var dashboardFieldTypes = []string{
+	"integer", "string", "host_group", "host", "item", "item_prototype", "graph", "graph_prototype", "map",
+	"service", "sla", "user", "action", "media_type",
+}
+
+// dashboardDisplayPeriods are the slideshow periods accepted by Zabbix, in seconds.
+var dashboardDisplayPeriods = []int64{10, 30, 60, 120, 600, 1800, 3600}
+
+type dashboardResource struct {
+	client *zabbix.Client
+}
+
+// dashboardModel holds the attributes shared by zabbix_dashboard and zabbix_template_dashboard.
+type dashboardModel struct {
+	ID            types.String         `tfsdk:"id"`
+	Name          types.String         `tfsdk:"name"`
+	DisplayPeriod types.Int64          `tfsdk:"display_period"`
+	AutoStart     types.Bool           `tfsdk:"auto_start"`
+	Pages         []dashboardPageModel `tfsdk:"page"`
+}
+
+type dashboardResourceModel struct {
+	dashboardModel
+	Private    types.Bool            `tfsdk:"private"`
+	OwnerID    types.String          `tfsdk:"owner_id"`
+	Users      []dashboardShareModel `tfsdk:"users"`
+	UserGroups []dashboardShareModel `tfsdk:"user_groups"`
+}
+
+type dashboardShareModel struct {
+	ID         types.String `tfsdk:"id"`
+	Permission types.String `tfsdk:"permission"`
+}
+
+type dashboardPageModel struct {
+	Name          types.String           `tfsdk:"name"`
+	DisplayPeriod types.Int64            `tfsdk:"display_period"`
+	Widgets       []dashboardWidgetModel `tfsdk:"widget"`
+}
+
+type dashboardWidgetModel struct {
+	Type       types.String                `tfsdk:"type"`
+	Name       types.String                `tfsdk:"name"`
+	X          types.Int64                 `tfsdk:"x"`
+	Y          types.Int64                 `tfsdk:"y"`
+	Width      types.Int64                 `tfsdk:"width"`
+	Height     types.Int64                 `tfsdk:"height"`
+	HideHeader types.Bool                  `tfsdk:"hide_header"`
+	Fields     []dashboardWidgetFieldModel `tfsdk:"field"`
+	FieldsJSON types.String                `tfsdk:"fields_json"`
+}
+
+type dashboardWidgetFieldModel struct {
+	Type  types.String `tfsdk:"type"`
+	Name  types.String `tfsdk:"name"`
+	Value types.String `tfsdk:"value"`
+}
+
+func NewDashboardResource() resource.Resource {
+	return &dashboardResource{}
+}
+
+func (r *dashboardResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_dashboard"
+}
+
+// dashboardAttributes returns the attributes shared by dashboards and template dashboards.
+func dashboardAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"id": schema.StringAttribute{
+			Computed: true,
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.UseStateForUnknown(),
+			},
+			MarkdownDescription: "Internal Zabbix ID.",
+		},
+		"name": schema.StringAttribute{
+			Required:            true,
+			MarkdownDescription: "Dashboard name.",
+		},
+		"display_period": schema.Int64Attribute{
+			Optional:            true,
+			Computed:            true,
+			Default:             int64default.StaticInt64(30),
+			MarkdownDescription: "Default page display period of the slideshow, in seconds: 10, 30, 60, 120, 600, 1800 or 3600. Default: 30.",
+		},
+		"auto_start": schema.BoolAttribute{
+			Optional:            true,
+			Computed:            true,
+			Default:             booldefault.StaticBool(true),
+			MarkdownDescription: "Start the slideshow automatically when the dashboard has several pages. Default: true.",
+		},
+	}
+}
+
+// dashboardPageBlock is the page block of dashboards and template dashboards.
+func dashboardPageBlock() schema.ListNestedBlock {
+	return schema.ListNestedBlock{
+		MarkdownDescription: "Dashboard page, in block order.",
+		NestedObject: schema.NestedBlockObject{
+			Attributes: map[string]schema.Attribute{
+				"name": schema.StringAttribute{
+					Optional:            true,
+					MarkdownDescription: "Page name.",
+				},
+				"display_period": schema.Int64Attribute{
+					Optional:            true,
+					Computed:            true,
+					Default:             int64default.StaticInt64(0),
+					MarkdownDescription: "Page display period in seconds, 0 for the dashboard default. Default: 0.",
+				},
+			},
+			Blocks: map[string]schema.Block{
+				"widget": schema.ListNestedBlock{
+					MarkdownDescription: "Widget of the page.",
+					NestedObject: schema.NestedBlockObject{
+						Attributes: map[string]schema.Attribute{
+							"type": schema.StringAttribute{
+								Required:            true,
+								MarkdownDescription: "Widget type, e.g. `graph`, `svggraph`, `problems`, `item`, `clock`, `plaintext`, `tophosts`.",
+							},
+							"name": schema.StringAttribute{
+								Optional:            true,
+								MarkdownDescription: "Widget name; the widget default when unset.",
+							},
+							"x": schema.Int64Attribute{
+								Optional:            true,
+								Computed:            true,
+								Default:             int64default.StaticInt64(0),
+								MarkdownDescription: "Column of the left edge. Default: 0.",
+							},
+							"y": schema.Int64Attribute{
+								Optional:            true,
+								Computed:            true,
+								Default:             int64default.StaticInt64(0),
+								MarkdownDescription: "Row of the top edge. Default: 0.",
+							},
+							"width": schema.Int64Attribute{
+								Required:            true,
+								MarkdownDescription: "Width in columns (72 columns on Zabbix 7.0, 24 before).",
+							},
+							"height": schema.Int64Attribute{
+								Required:            true,
+								MarkdownDescription: "Height in rows.",
+							},
+							"hide_header": schema.BoolAttribute{
+								Optional:            true,
+								Computed:            true,
+								Default:             booldefault.StaticBool(false),
+								MarkdownDescription: "Hide the widget header. Default: false.",
+							},
+							"fields_json": schema.StringAttribute{
+								Optional:            true,
+								MarkdownDescription: "Widget fields as a raw JSON list in Zabbix format, e.g. `[{\"type\": 0, \"name\": \"rf_rate\", \"value\": \"60\"}]`. Alternative to `field` blocks.",
+							},
+						},
+						Blocks: map[string]schema.Block{
+							"field": schema.ListNestedBlock{
+								MarkdownDescription: "Widget field.",
+								NestedObject: schema.NestedBlockObject{
+									Attributes: map[string]schema.Attribute{
+										"type": schema.StringAttribute{
+											Required:            true,
+											MarkdownDescription: "Field type: `integer`, `string`, `host_group`, `host`, `item`, `item_prototype`, `graph`, `graph_prototype`, `map`, `service`, `sla`, `user`, `action` or `media_type`.",
+											Validators: []validator.String{
+												stringOneOf(dashboardFieldTypes...),
+											},
+										},
+										"name": schema.StringAttribute{
+											Required:            true,
+											MarkdownDescription: "Field name, e.g. `rf_rate` or `groupids.0`.",
+										},
+										"value": schema.StringAttribute{
+											Required:            true,
+											MarkdownDescription: "Field value; the object ID for host group, host, item, graph, ... fields.",
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dashboardShareBlock(description, idDescription string) schema.SetNestedBlock {
+	return schema.SetNestedBlock{
+		MarkdownDescription: description,
+		NestedObject: schema.NestedBlockObject{
+			Attributes: map[string]schema.Attribute{
+				"id": schema.StringAttribute{
+					Required:            true,
+					MarkdownDescription: idDescription,
+				},
+				"permission": schema.StringAttribute{
+					Required:            true,
+					MarkdownDescription: "Access level: `read` or `read-write`.",
+					Validators: []validator.String{
+						stringOneOf("read", "read-write"),
+					},
+				},
+			},
+		},
+	}
+}
+
+func (r *dashboardResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	attributes := dashboardAttributes()
+	attributes["private"] = schema.BoolAttribute{
+		Optional:            true,
+		Computed:            true,
+		Default:             booldefault.StaticBool(true),
+		MarkdownDescription: "Only the owner and the users and user groups of `users` and `user_groups` see the dashboard. Default: true.",
+	}
+	attributes["owner_id"] = schema.StringAttribute{
+		Optional: true,
+		Computed: true,
+		PlanModifiers: []planmodifier.String{
+			stringplanmodifier.UseStateForUnknown(),
+		},
+		MarkdownDescription: "ID of the owner; the API user when unset.",
+	}
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Zabbix dashboard resource: pages of widgets, sharing and slideshow settings.",
+		Attributes:          attributes,
+		Blocks: map[string]schema.Block{
+			"page": dashboardPageBlock(),
+			"users": dashboardShareBlock(
+				"User the dashboard is shared with.",
+				"User ID.",
+			),
+			"user_groups": dashboardShareBlock(
+				"User group the dashboard is shared with.",
+				"User group ID.",
+			),
+		},
+	}
+}
+
+func (r *dashboardResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	providerData, ok := req.ProviderData.(*providerData)
+	if !ok || providerData.Client == nil {
+		resp.Diagnostics.AddError("Invalid provider", "Zabbix client unavailable.")
+		return
+	}
+	r.client = providerData.Client
+}
+
+func (r *dashboardResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config dashboardResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	validateDashboard(config.dashboardModel, &resp.Diagnostics)
+}
+
+func (r *dashboardResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan dashboardResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	zreq, d := expandDashboard(plan)
+	resp.Diagnostics.Append(d...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	id, err := r.client.DashboardCreate(ctx, zreq)
+	if err != nil {
+		resp.Diagnostics.AddError("dashboard.create error", err.Error())
+		return
+	}
+
+	// Read back the owner when it defaults to the API user.
+	dashboard, err := r.client.DashboardGetByID(ctx, id)
+	if err != nil {
+		resp.Diagnostics.AddError("dashboard.get error", err.Error())
+		return
+	}
+	plan.ID = types.StringValue(id)
+	plan.OwnerID = types.StringValue(dashboard.UserID)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *dashboardResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state dashboardResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	dashboard, err := r.client.DashboardGetByID(ctx, state.ID.ValueString())
+	if err != nil {
+		if zabbix.IsNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError("dashboard.get error", err.Error())
+		return
+	}
+
+	state.Name = types.StringValue(dashboard.Name)
+	state.DisplayPeriod = types.Int64Value(int64(dashboard.DisplayPeriod))
+	state.AutoStart = types.BoolValue(dashboard.AutoStart == "1")
+	state.Pages = flattenDashboardPages(dashboard.Pages, state.Pages)
+	state.Private = types.BoolValue(dashboard.Private == "1")
+	state.OwnerID = types.StringValue(dashboard.UserID)
+
+	state.Users = make([]dashboardShareModel, 0, len(dashboard.Users))
+	for _, u := range dashboard.Users {
+		state.Users = append(state.Users, dashboardShareModel{
+			ID:         types.StringValue(u.UserID),
+			Permission: types.StringValue(dashboardPermissionName(u.Permission)),
+		})
+	}
+	state.UserGroups = make([]dashboardShareModel, 0, len(dashboard.UserGroups))
+	for _, g := range dashboard.UserGroups {
+		state.UserGroups = append(state.UserGroups, dashboardShareModel{
+			ID:         types.StringValue(g.UserGroupID),
+			Permission: types.StringValue(dashboardPermissionName(g.Permission)),
+		})
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *dashboardResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan dashboardResourceModel
+	var state dashboardResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	zreq, d := expandDashboard(plan)
+	resp.Diagnostics.Append(d...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if err := r.client.DashboardUpdate(ctx, state.ID.ValueString(), zreq); err != nil {
+		resp.Diagnostics.AddError("dashboard.update error", err.Error())
+		return
+	}
+
+	plan.ID = state.ID
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *dashboardResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state dashboardResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	err := r.client.DashboardDelete(ctx, state.ID.ValueString())
+	if err != nil && !zabbix.IsNotFound(err) {
+		resp.Diagnostics.AddError("dashboard.delete error", err.Error())
+	}
+}
+
+func (r *dashboardResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+// expandDashboard builds the dashboard.create/update request from the plan.
+func expandDashboard(plan dashboardResourceModel) (zabbix.DashboardCreateRequest, diag.Diagnostics) {
+	pages, diags := expandDashboardPages(plan.Pages)
+	req := zabbix.DashboardCreateRequest{
+		Name:          plan.Name.ValueString(),
+		DisplayPeriod: int(plan.DisplayPeriod.ValueInt64()),
+		AutoStart:     plan.AutoStart.ValueBool(),
+		Private:       plan.Private.ValueBool(),
+		OwnerID:       nullableString(plan.OwnerID),
+		Pages:         pages,
+	}
+	for _, u := range plan.Users {
+		req.Users = append(req.Users, zabbix.DashboardShare{ID: u.ID.ValueString(), Permission: nameToCode(userGroupPermissions, u.Permission.ValueString())})
+	}
+	for _, g := range plan.UserGroups {
+		req.UserGroups = append(req.UserGroups, zabbix.DashboardShare{ID: g.ID.ValueString(), Permission: nameToCode(userGroupPermissions, g.Permission.ValueString())})
+	}
+	return req, diags
+}
+
+// dashboardPermissionName maps a Zabbix sharing permission code back to its schema name.
+func dashboardPermissionName(code string) string {
+	if code == zabbix.UsergroupPermissionReadWrite {
+		return "read-write"
+	}
+	return "read"
+}
+
+// validateDashboard checks the display periods and the widgets of dashboards and template dashboards.
+func validateDashboard(config dashboardModel, diags *diag.Diagnostics) {
+	if !config.DisplayPeriod.IsNull() && !config.DisplayPeriod.IsUnknown() && !slices.Contains(dashboardDisplayPeriods, config.DisplayPeriod.ValueInt64()) {
+		diags.AddAttributeError(path.Root("display_period"), "Invalid attribute value", "`display_period` is one of 10, 30, 60, 120, 600, 1800 or 3600 seconds.")
+	}
+	for i, page := range config.Pages {
+		pagePath := path.Root("page").AtListIndex(i)
+		if v := page.DisplayPeriod; !v.IsNull() && !v.IsUnknown() && v.ValueInt64() != 0 && !slices.Contains(dashboardDisplayPeriods, v.ValueInt64()) {
+			diags.AddAttributeError(pagePath.AtName("display_period"), "Invalid attribute value", "Page `display_period` is 0 (dashboard default) or one of 10, 30, 60, 120, 600, 1800 or 3600 seconds.")
+		}
+		for j, widget := range page.Widgets {
+			widgetPath := pagePath.AtName("widget").AtListIndex(j)
+			for _, size := range []struct {
+				name  string
+				value types.Int64
+				min   int64
+			}{{"x", widget.X, 0}, {"y", widget.Y, 0}, {"width", widget.Width, 1}, {"height", widget.Height, 1}} {
+				if !size.value.IsNull() && !size.value.IsUnknown() && size.value.ValueInt64() < size.min {
+					diags.AddAttributeError(widgetPath.AtName(size.name), "Invalid attribute value", fmt.Sprintf("`%s` is at least %d.", size.name, size.min))
+				}
+			}
+			if widget.FieldsJSON.IsNull() || widget.FieldsJSON.IsUnknown() {
+				continue
+			}
+			if len(widget.Fields) > 0 {
+				diags.AddAttributeError(widgetPath.AtName("fields_json"), "Invalid attribute combination", "`fields_json` and `field` blocks cannot be used together.")
+			}
+			if _, err := parseWidgetFieldsJSON(widget.FieldsJSON.ValueString()); err != nil {
+				diags.AddAttributeError(widgetPath.AtName("fields_json"), "Invalid JSON", fmt.Sprintf("`fields_json` is not a list of widget fields (`{\"type\": 0, \"name\": \"...\", \"value\": \"...\"}`): %s", err))
+			}
+		}
+	}
+}
+
+// parseWidgetFieldsJSON decodes the fields_json attribute of a widget.
+func parseWidgetFieldsJSON(value string) ([]zabbix.DashboardWidgetField, error) {
+	var fields []zabbix.DashboardWidgetField
+	if err := json.Unmarshal([]byte(value), &fields); err != nil {
+		return nil, err
+	}
+	for i, field := range fields {
+		if field.Name == "" {
+			return nil, fmt.Errorf("field %d has no name", i)
+		}
+	}
+	return fields, nil
+}
+
+// expandDashboardPages builds the pages of dashboard and template dashboard requests.
+func expandDashboardPages(pages []dashboardPageModel) ([]zabbix.DashboardPage, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	out := make([]zabbix.DashboardPage, 0, len(pages))
+	for i, page := range pages {
+		zpage := zabbix.DashboardPage{
+			Name:          nullableString(page.Name),
+			DisplayPeriod: zabbix.FlexInt(page.DisplayPeriod.ValueInt64()),
+			Widgets:       make([]zabbix.DashboardWidget, 0, len(page.Widgets)),
+		}
+		for j, widget := range page.Widgets {
+			zwidget := zabbix.DashboardWidget{
+				Type:     widget.Type.ValueString(),
+				Name:     nullableString(widget.Name),
+				X:        zabbix.FlexInt(widget.X.ValueInt64()),
+				Y:        zabbix.FlexInt(widget.Y.ValueInt64()),
+				Width:    zabbix.FlexInt(widget.Width.ValueInt64()),
+				Height:   zabbix.FlexInt(widget.Height.ValueInt64()),
+				ViewMode: zabbix.FlexInt(zabbix.BoolToInt(widget.HideHeader.ValueBool())),
+			}
+			if !widget.FieldsJSON.IsNull() {
+				fields, err := parseWidgetFieldsJSON(widget.FieldsJSON.ValueString())
+				if err != nil {
+					diags.AddAttributeError(path.Root("page").AtListIndex(i).AtName("widget").AtListIndex(j).AtName("fields_json"), "Invalid JSON", err.Error())
+					continue
+				}
+				zwidget.Fields = fields
+			}
+			for _, field := range widget.Fields {
+				zwidget.Fields = append(zwidget.Fields, zabbix.DashboardWidgetField{
+					Type:  zabbix.FlexInt(atoi64(nameToCode(dashboardFieldTypes, field.Type.ValueString()))),
+					Name:  field.Name.ValueString(),
+					Value: field.Value.ValueString(),
+				})
+			}
+			zpage.Widgets = append(zpage.Widgets, zwidget)
+		}
+		out = append(out, zpage)
+	}
+	return out, diags
+}
+
+// flattenDashboardPages converts the pages read from the API. Widgets and widget fields, which Zabbix returns
+// in its own order, follow the order of the prior state so that plans do not churn.
+func flattenDashboardPages(pages []zabbix.DashboardPage, prior []dashboardPageModel) []dashboardPageModel {
+	out := make([]dashboardPageModel, 0, len(pages))
+	for i, page := range pages {
+		var priorWidgets []dashboardWidgetModel
+		if i < len(prior) {
+			priorWidgets = prior[i].Widgets
+		}
+		widgets := orderDashboardWidgets(page.Widgets, priorWidgets)
+		model := dashboardPageModel{
+			Name:          nullOrString(page.Name),
+			DisplayPeriod: types.Int64Value(int64(page.DisplayPeriod)),
+			Widgets:       make([]dashboardWidgetModel, 0, len(widgets)),
+		}
+		for j, widget := range widgets {
+			var priorWidget *dashboardWidgetModel
+			if j < len(priorWidgets) {
+				priorWidget = &priorWidgets[j]
+			}
+			model.Widgets = append(model.Widgets, flattenDashboardWidget(widget, priorWidget))
+		}
+		out = append(out, model)
+	}
+	return out
+}
+
+// orderDashboardWidgets puts the widgets in the order of the prior state, matched on type and position;
+// other widgets follow, top to bottom and left to right.
+func orderDashboardWidgets(widgets []zabbix.DashboardWidget, prior []dashboardWidgetModel) []zabbix.DashboardWidget {
+	out := make([]zabbix.DashboardWidget, 0, len(widgets))
+	used := make([]bool, len(widgets))
+	for _, p := range prior {
+		for i, w := range widgets {
+			if !used[i] && w.Type == p.Type.ValueString() && int64(w.X) == p.X.ValueInt64() && int64(w.Y) == p.Y.ValueInt64() {
+				used[i] = true
+				out = append(out, w)
+				break
+			}
+		}
+	}
+	var rest []zabbix.DashboardWidget
+	for i, w := range widgets {
+		if !used[i] {
+			rest = append(rest, w)
+		}
+	}
+	sort.SliceStable(rest, func(i, j int) bool {
+		if rest[i].Y != rest[j].Y {
+			return rest[i].Y < rest[j].Y
+		}
+		return rest[i].X < rest[j].X
+	})
+	return append(out, rest...)
+}
+
+func flattenDashboardWidget(widget zabbix.DashboardWidget, prior *dashboardWidgetModel) dashboardWidgetModel {
+	model := dashboardWidgetModel{
+		Type:       types.StringValue(widget.Type),
+		Name:       nullOrString(widget.Name),
+		X:          types.Int64Value(int64(widget.X)),
+		Y:          types.Int64Value(int64(widget.Y)),
+		Width:      types.Int64Value(int64(widget.Width)),
+		Height:     types.Int64Value(int64(widget.Height)),
+		HideHeader: types.BoolValue(widget.ViewMode == 1),
+		Fields:     make([]dashboardWidgetFieldModel, 0),
+		FieldsJSON: types.StringNull(),
+	}
+
+	if prior != nil && !prior.FieldsJSON.IsNull() {
+		priorFields, _ := parseWidgetFieldsJSON(prior.FieldsJSON.ValueString())
+		fields := orderWidgetFields(widget.Fields, priorFields)
+		if slices.Equal(fields, priorFields) {
+			model.FieldsJSON = prior.FieldsJSON
+		} else {
+			encoded, _ := json.Marshal(fields)
+			model.FieldsJSON = types.StringValue(string(encoded))
+		}
+		return model
+	}
+
+	var priorFields []zabbix.DashboardWidgetField
+	if prior != nil {
+		for _, field := range prior.Fields {
+			priorFields = append(priorFields, zabbix.DashboardWidgetField{
+				Type:  zabbix.FlexInt(atoi64(nameToCode(dashboardFieldTypes, field.Type.ValueString()))),
+				Name:  field.Name.ValueString(),
+				Value: field.Value.ValueString(),
+			})
+		}
+	}
+	for _, field := range orderWidgetFields(widget.Fields, priorFields) {
+		code := strconv.Itoa(int(field.Type))
+		model.Fields = append(model.Fields, dashboardWidgetFieldModel{
+			Type:  types.StringValue(codeToName(dashboardFieldTypes, code, code)),
+			Name:  types.StringValue(field.Name),
+			Value: types.StringValue(field.Value),
+		})
+	}
+	return model
+}
+
+// orderWidgetFields puts the fields in the order of the prior fields: exact matches first, then fields of the
+// same type and name (changed values). Other fields follow, sorted by name.
+func orderWidgetFields(fields, prior []zabbix.DashboardWidgetField) []zabbix.DashboardWidgetField {
+	matched := make([]int, len(prior))
+	used := make([]bool, len(fields))
+	for i, p := range prior {
+		matched[i] = -1
+		for j, f := range fields {
+			if !used[j] && f == p {
+				matched[i], used[j] = j, true
+				break
+			}
+		}
+	}
+	for i, p := range prior {
+		if matched[i] >= 0 {
+			continue
+		}
+		for j, f := range fields {
+			if !used[j] && f.Type == p.Type && f.Name == p.Name {
+				matched[i], used[j] = j, true
+				break
+			}
+		}
+	}
+
+	out := make([]zabbix.DashboardWidgetField, 0, len(fields))
+	for _, j := range matched {
+		if j >= 0 {
+			out = append(out, fields[j])
+		}
+	}
+	var rest []zabbix.DashboardWidgetField
+	for j, f := range fields {
+		if !used[j] {
+			rest = append(rest, f)
+		}
+	}
+	sort.SliceStable(rest, func(i, j int) bool { return rest[i].Name < rest[j].Name })
+	return append(out, rest...)
+}
@@ -0,0 +1,793 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/rushiii/terraform-provider-zabbix/internal/zabbix"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// discoveryRuleTypes are the item types of discovery rules (no calculated and SNMP trap rules).
+var discoveryRuleTypes = []int64{
+	zabbix.ItemTypeZabbixAgent, zabbix.ItemTypeTrapper, zabbix.ItemTypeSimpleCheck, zabbix.ItemTypeInternal,
+	zabbix.ItemTypeZabbixAgentActive, zabbix.ItemTypeExternal, zabbix.ItemTypeDatabaseMonitor, zabbix.ItemTypeIPMI,
+	zabbix.ItemTypeSSH, zabbix.ItemTypeTelnet, zabbix.ItemTypeJMX, zabbix.ItemTypeDependent,
+	zabbix.ItemTypeHTTPAgent, zabbix.ItemTypeSNMPAgent, zabbix.ItemTypeScript,
+}
+
+// Enums of discovery rules, indexed by Zabbix code.
+var (
+	lldLifetimeTypes      = []string{"after", "never", "immediately"}
+	lldConditionOperators = []string{
+		zabbix.LLDOperatorMatches:    "matches",
+		zabbix.LLDOperatorNotMatches: "not_matches",
+		zabbix.LLDOperatorExists:     "exists",
+		zabbix.LLDOperatorNotExists:  "not_exists",
+	}
+	lldOperationObjects   = []string{"item_prototype", "trigger_prototype", "graph_prototype", "host_prototype"}
+	lldOperationOperators = []string{"equals", "not_equals", "contains", "not_contains", "matches", "not_matches"}
+	// lldInventoryModes are indexed by inventory mode + 1 (-1 disabled, 0 manual, 1 automatic).
+	lldInventoryModes = []string{"disabled", "manual", "automatic"}
+)
+
+// lldMacro matches LLD macros, e.g. {#IFNAME}; lldMacroRef finds them in keys and names.
+var (
+	lldMacro    = regexp.MustCompile(`^\{#[A-Z0-9_.]+\}$`)
+	lldMacroRef = regexp.MustCompile(`\{#[A-Z0-9_.]+\}`)
+)
+
+// lldOperationAttributes lists the override operation attributes with the objects that use them.
+var lldOperationAttributes = map[string][]string{
+	"enabled":        {"item_prototype", "trigger_prototype", "host_prototype"},
+	"discover":       lldOperationObjects,
+	"delay":          {"item_prototype"},
+	"history":        {"item_prototype"},
+	"trends":         {"item_prototype"},
+	"severity":       {"trigger_prototype"},
+	"tags":           {"item_prototype", "trigger_prototype", "host_prototype"},
+	"template_ids":   {"host_prototype"},
+	"inventory_mode": {"host_prototype"},
+}
+
+var (
+	_ resource.Resource                   = &discoveryRuleResource{}
+	_ resource.ResourceWithConfigure      = &discoveryRuleResource{}
+	_ resource.ResourceWithImportState    = &discoveryRuleResource{}
+	_ resource.ResourceWithValidateConfig = &discoveryRuleResource{}
+)
+
+type discoveryRuleResource struct {
+	client *zabbix.Client
+}
+
+type discoveryRuleResourceModel struct {
+	itemSourceModel
+	Lifetime            types.String        `tfsdk:"lifetime"`
+	LifetimeType        types.String        `tfsdk:"lifetime_type"`
+	EnabledLifetime     types.String        `tfsdk:"enabled_lifetime"`
+	EnabledLifetimeType types.String        `tfsdk:"enabled_lifetime_type"`
+	EvalType            types.String        `tfsdk:"evaltype"`
+	Formula             types.String        `tfsdk:"formula"`
+	Conditions          []lldConditionModel `tfsdk:"condition"`
+	LLDMacroPaths       types.Map           `tfsdk:"lld_macro_paths"`
+	Overrides           []lldOverrideModel  `tfsdk:"override"`
+}
+
+type lldConditionModel struct {
+	Macro    types.String `tfsdk:"macro"`
+	Operator types.String `tfsdk:"operator"`
+	Value    types.String `tfsdk:"value"`
+}
+
+type lldOverrideModel struct {
+	Name       types.String        `tfsdk:"name"`
+	Stop       types.Bool          `tfsdk:"stop"`
+	EvalType   types.String        `tfsdk:"evaltype"`
+	Formula    types.String        `tfsdk:"formula"`
+	Conditions []lldConditionModel `tfsdk:"condition"`
+	Operations []lldOperationModel `tfsdk:"operation"`
+}
+
+type lldOperationModel struct {
+	Object        types.String `tfsdk:"object"`
+	Operator      types.String `tfsdk:"operator"`
+	Value         types.String `tfsdk:"value"`
+	Enabled       types.Bool   `tfsdk:"enabled"`
+	Discover      types.Bool   `tfsdk:"discover"`
+	Delay         types.String `tfsdk:"delay"`
+	History       types.String `tfsdk:"history"`
+	Trends        types.String `tfsdk:"trends"`
+	Severity      types.String `tfsdk:"severity"`
+	Tags          types.Map    `tfsdk:"tags"`
+	TemplateIDs   types.Set    `tfsdk:"template_ids"`
+	InventoryMode types.String `tfsdk:"inventory_mode"`
+}
+
+func NewDiscoveryRuleResource() resource.Resource {
+	return &discoveryRuleResource{}
+}
+
+func (r *discoveryRuleResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_discovery_rule"
+}
+
+// lldFilterAttributes returns the evaltype and formula attributes of a filter.
+func lldFilterAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"evaltype": schema.StringAttribute{
+			Optional:            true,
+			Computed:            true,
+			Default:             stringdefault.StaticString("and_or"),
+			MarkdownDescription: "How filter conditions are combined: `and_or` (AND between macros, OR within a macro), `and`, `or` or `custom` (`formula`).",
+			Validators: []validator.String{
+				stringOneOf(actionEvalTypes...),
+			},
+		},
+		"formula": schema.StringAttribute{
+			Optional:            true,
+			MarkdownDescription: "Custom filter formula with `evaltype = \"custom\"`, e.g. `A and (B or C)`.",
+		},
+	}
+}
+
+// lldConditionBlock returns the schema block of filter conditions.
+func lldConditionBlock() schema.ListNestedBlock {
+	return schema.ListNestedBlock{
+		MarkdownDescription: "Filter condition on an LLD macro. With `evaltype = \"custom\"`, conditions are labelled A, B, C, ... in block order.",
+		NestedObject: schema.NestedBlockObject{
+			Attributes: map[string]schema.Attribute{
+				"macro": schema.StringAttribute{
+					Required:            true,
+					MarkdownDescription: "LLD macro, e.g. `{#IFNAME}`.",
+				},
+				"operator": schema.StringAttribute{
+					Optional:            true,
+					Computed:            true,
+					Default:             stringdefault.StaticString("matches"),
+					MarkdownDescription: "`matches`, `not_matches` (regular expression), `exists` or `not_exists` (Zabbix 5.0+).",
+					Validators: []validator.String{
+						stringOneOf("matches", "not_matches", "exists", "not_exists"),
+					},
+				},
+				"value": schema.StringAttribute{
+					Optional:            true,
+					Computed:            true,
+					Default:             stringdefault.StaticString(""),
+					MarkdownDescription: "Regular expression, or global regular expression prefixed with `@`. Not used by `exists` and `not_exists`.",
+				},
+			},
+		},
+	}
+}
+
+func (r *discoveryRuleResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	attributes := itemSourceAttributes()
+	for name, attribute := range lldFilterAttributes() {
+		attributes[name] = attribute
+	}
+	attributes["host_id"] = schema.StringAttribute{
+		Required: true,
+		PlanModifiers: []planmodifier.String{
+			stringplanmodifier.RequiresReplace(),
+		},
+		MarkdownDescription: "ID of the host or template to attach the discovery rule to. Changing this forces recreation.",
+	}
+	attributes["name"] = schema.StringAttribute{
+		Required:            true,
+		MarkdownDescription: "Discovery rule name (e.g. Network interface discovery).",
+	}
+	attributes["key"] = schema.StringAttribute{
+		Required:            true,
+		MarkdownDescription: "Discovery rule key (e.g. net.if.discovery, vfs.fs.discovery), unique among the items and rules of the host.",
+	}
+	attributes["type"] = schema.Int64Attribute{
+		Optional: true,
+		Computed: true,
+		Default:  int64default.StaticInt64(0),
+		PlanModifiers: []planmodifier.Int64{
+			int64planmodifier.RequiresReplace(),
+		},
+		MarkdownDescription: "Rule type: 0=Zabbix agent, 2=Zabbix trapper, 3=Simple check, 5=Zabbix internal, 7=Zabbix agent (active), 10=External check, 11=Database monitor, 12=IPMI agent, 13=SSH agent, 14=TELNET agent, 16=JMX agent, 18=Dependent item, 19=HTTP agent, 20=SNMP agent, 21=Script. Changing this forces recreation.",
+	}
+	attributes["lifetime"] = schema.StringAttribute{
+		Optional: true,
+		Computed: true,
+		PlanModifiers: []planmodifier.String{
+			stringplanmodifier.UseStateForUnknown(),
+		},
+		MarkdownDescription: "How long discovered entities that are no longer discovered are kept, e.g. `7d` (unset: the Zabbix default, `30d` before 7.0 and `7d` since).",
+	}
+	attributes["lifetime_type"] = schema.StringAttribute{
+		Optional: true,
+		Computed: true,
+		PlanModifiers: []planmodifier.String{
+			stringplanmodifier.UseStateForUnknown(),
+		},
+		MarkdownDescription: "Zabbix 7.0+: when lost entities are deleted: `after` (`lifetime`), `never` or `immediately`.",
+		Validators: []validator.String{
+			stringOneOf(lldLifetimeTypes...),
+		},
+	}
+	attributes["enabled_lifetime"] = schema.StringAttribute{
+		Optional: true,
+		Computed: true,
+		PlanModifiers: []planmodifier.String{
+			stringplanmodifier.UseStateForUnknown(),
+		},
+		MarkdownDescription: "Zabbix 7.0+: how long lost entities stay enabled with `enabled_lifetime_type = \"after\"`, e.g. `1d`.",
+	}
+	attributes["enabled_lifetime_type"] = schema.StringAttribute{
+		Optional: true,
+		Computed: true,
+		PlanModifiers: []planmodifier.String{
+			stringplanmodifier.UseStateForUnknown(),
+		},
+		MarkdownDescription: "Zabbix 7.0+: when lost entities are disabled: `after` (`enabled_lifetime`), `never` or `immediately`.",
+		Validators: []validator.String{
+			stringOneOf(lldLifetimeTypes...),
+		},
+	}
+	attributes["lld_macro_paths"] = schema.MapAttribute{
+		Optional:            true,
+		ElementType:         types.StringType,
+		MarkdownDescription: "LLD macros extracted from the discovered JSON objects (macro => JSONPath), e.g. `{\"{#IFNAME}\" = \"$.name\"}`.",
+	}
+
+	overrideAttributes := lldFilterAttributes()
+	overrideAttributes["name"] = schema.StringAttribute{
+		Required:            true,
+		MarkdownDescription: "Override name, unique in the rule.",
+	}
+	overrideAttributes["stop"] = schema.BoolAttribute{
+		Optional:            true,
+		Computed:            true,
+		Default:             booldefault.StaticBool(false),
+		MarkdownDescription: "Stop processing the next overrides when the filter matches.",
+	}
+
+	blocks := itemBlocks()
+	blocks["condition"] = lldConditionBlock()
+	blocks["override"] = schema.ListNestedBlock{
+		MarkdownDescription: "Override applied to the discovered prototypes, in block order.",
+		NestedObject: schema.NestedBlockObject{
+			Attributes: overrideAttributes,
+			Blocks: map[string]schema.Block{
+				"condition": lldConditionBlock(),
+				"operation": schema.ListNestedBlock{
+					MarkdownDescription: "Changes applied to the prototypes of an object type whose name matches `operator` and `value`.",
+					NestedObject: schema.NestedBlockObject{
+						Attributes: map[string]schema.Attribute{
+							"object": schema.StringAttribute{
+								Required:            true,
+								MarkdownDescription: "`item_prototype`, `trigger_prototype`, `graph_prototype` or `host_prototype`.",
+								Validators: []validator.String{
+									stringOneOf(lldOperationObjects...),
+								},
+							},
+							"operator": schema.StringAttribute{
+								Optional:            true,
+								Computed:            true,
+								Default:             stringdefault.StaticString("equals"),
+								MarkdownDescription: "Prototype name condition: `equals`, `not_equals`, `contains`, `not_contains`, `matches` or `not_matches`.",
+								Validators: []validator.String{
+									stringOneOf(lldOperationOperators...),
+								},
+							},
+							"value": schema.StringAttribute{
+								Optional:            true,
+								Computed:            true,
+								Default:             stringdefault.StaticString(""),
+								MarkdownDescription: "Prototype name, substring or regular expression. Empty with `contains` and `matches` selects every prototype.",
+							},
+							"enabled": schema.BoolAttribute{
+								Optional:            true,
+								MarkdownDescription: "Create the entities enabled or disabled (item, trigger and host prototypes).",
+							},
+							"discover": schema.BoolAttribute{
+								Optional:            true,
+								MarkdownDescription: "Whether the entities are discovered.",
+							},
+							"delay": schema.StringAttribute{
+								Optional:            true,
+								MarkdownDescription: "Update interval (item prototypes).",
+							},
+							"history": schema.StringAttribute{
+								Optional:            true,
+								MarkdownDescription: "History storage period (item prototypes).",
+							},
+							"trends": schema.StringAttribute{
+								Optional:            true,
+								MarkdownDescription: "Trends storage period (item prototypes).",
+							},
+							"severity": schema.StringAttribute{
+								Optional:            true,
+								MarkdownDescription: "Severity 0..5 (trigger prototypes).",
+								Validators: []validator.String{
+									stringOneOf("0", "1", "2", "3", "4", "5"),
+								},
+							},
+							"tags": schema.MapAttribute{
+								Optional:            true,
+								ElementType:         types.StringType,
+								MarkdownDescription: "Tags added to the entities (item, trigger and host prototypes).",
+							},
+							"template_ids": schema.SetAttribute{
+								Optional:            true,
+								ElementType:         types.StringType,
+								MarkdownDescription: "Templates linked to the discovered hosts (host prototypes).",
+							},
+							"inventory_mode": schema.StringAttribute{
+								Optional:            true,
+								MarkdownDescription: "Inventory mode of the discovered hosts: `disabled`, `manual` or `automatic` (host prototypes).",
+								Validators: []validator.String{
+									stringOneOf(lldInventoryModes...),
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Zabbix low-level discovery rule resource: discovers entities (interfaces, file systems, ...) and creates items, triggers, graphs and hosts from their prototypes. Supports the item types of `zabbix_item` except calculated and SNMP trap, with LLD filters, macro paths, overrides and preprocessing.",
+		Attributes:          attributes,
+		Blocks:              blocks,
+	}
+}
+
+func (r *discoveryRuleResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	providerData, ok := req.ProviderData.(*providerData)
+	if !ok || providerData.Client == nil {
+		resp.Diagnostics.AddError("Invalid provider", "Zabbix client unavailable.")
+		return
+	}
+	r.client = providerData.Client
+}
+
+func (r *discoveryRuleResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config discoveryRuleResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	validateItemSource(config.itemSourceModel, discoveryRuleTypes, &resp.Diagnostics)
+
+	if !config.EnabledLifetime.IsNull() && !config.EnabledLifetimeType.IsUnknown() && config.EnabledLifetimeType.ValueString() != "after" {
+		resp.Diagnostics.AddAttributeError(path.Root("enabled_lifetime"), "Invalid attribute combination", "`enabled_lifetime` requires `enabled_lifetime_type = \"after\"`.")
+	}
+	if !config.LLDMacroPaths.IsNull() && !config.LLDMacroPaths.IsUnknown() {
+		for macro := range config.LLDMacroPaths.Elements() {
+			if !lldMacro.MatchString(macro) {
+				resp.Diagnostics.AddAttributeError(path.Root("lld_macro_paths"), "Invalid attribute value", fmt.Sprintf("%q is not an LLD macro (e.g. `{#IFNAME}`).", macro))
+			}
+		}
+	}
+	validateLLDFilter(path.Empty(), config.EvalType, config.Formula, config.Conditions, &resp.Diagnostics)
+
+	for i, o := range config.Overrides {
+		overridePath := path.Root("override").AtListIndex(i)
+		validateLLDFilter(overridePath, o.EvalType, o.Formula, o.Conditions, &resp.Diagnostics)
+		for j, op := range o.Operations {
+			if op.Object.IsUnknown() {
+				continue
+			}
+			opPath := overridePath.AtName("operation").AtListIndex(j)
+			object := op.Object.ValueString()
+			values := map[string]attr.Value{
+				"enabled":        op.Enabled,
+				"discover":       op.Discover,
+				"delay":          op.Delay,
+				"history":        op.History,
+				"trends":         op.Trends,
+				"severity":       op.Severity,
+				"tags":           op.Tags,
+				"template_ids":   op.TemplateIDs,
+				"inventory_mode": op.InventoryMode,
+			}
+			set := false
+			for name, value := range values {
+				if value.IsNull() {
+					continue
+				}
+				set = true
+				if !slices.Contains(lldOperationAttributes[name], object) {
+					resp.Diagnostics.AddAttributeError(opPath.AtName(name), "Invalid attribute combination", fmt.Sprintf("`%s` is not used by `%s` operations.", name, object))
+				}
+			}
+			if !set {
+				resp.Diagnostics.AddAttributeError(opPath, "Missing attribute", "An operation requires at least one change, e.g. `discover` or `enabled`.")
+			}
+		}
+	}
+}
+
+// validateLLDFilter checks the conditions and the custom formula labels of a filter at base (the rule or
+// an override).
+func validateLLDFilter(base path.Path, evalType, formula types.String, conditions []lldConditionModel, diags *diag.Diagnostics) {
+	at := func(name string) path.Path {
+		if len(base.Steps()) == 0 {
+			return path.Root(name)
+		}
+		return base.AtName(name)
+	}
+	for i, c := range conditions {
+		conditionPath := at("condition").AtListIndex(i)
+		if !c.Macro.IsUnknown() && !lldMacro.MatchString(c.Macro.ValueString()) {
+			diags.AddAttributeError(conditionPath.AtName("macro"), "Invalid attribute value", fmt.Sprintf("%q is not an LLD macro (e.g. `{#IFNAME}`).", c.Macro.ValueString()))
+		}
+		operator := c.Operator.ValueString()
+		if !c.Operator.IsUnknown() && !c.Value.IsUnknown() && (operator == "exists" || operator == "not_exists") && c.Value.ValueString() != "" {
+			diags.AddAttributeError(conditionPath.AtName("value"), "Invalid attribute combination", fmt.Sprintf("`%s` conditions have no `value`.", operator))
+		}
+	}
+
+	if evalType.IsUnknown() || formula.IsUnknown() {
+		return
+	}
+	if evalType.ValueString() != "custom" {
+		if !formula.IsNull() {
+			diags.AddAttributeError(at("formula"), "Invalid attribute combination", "`formula` requires `evaltype = \"custom\"`.")
+		}
+		return
+	}
+	if formula.IsNull() {
+		diags.AddAttributeError(at("formula"), "Missing attribute", "`evaltype = \"custom\"` requires `formula`.")
+		return
+	}
+	labels := make(map[string]bool, len(conditions))
+	for i := range conditions {
+		labels[actionFormulaID(i)] = false
+	}
+	for _, token := range actionFormulaLabel.FindAllString(formula.ValueString(), -1) {
+		if _, ok := labels[token]; !ok {
+			diags.AddAttributeError(at("formula"), "Invalid formula", "Label "+token+" does not match any `condition` block.")
+			continue
+		}
+		labels[token] = true
+	}
+	for i := range conditions {
+		if !labels[actionFormulaID(i)] {
+			diags.AddAttributeError(at("formula"), "Invalid formula", "Condition "+actionFormulaID(i)+" is not used in the formula.")
+		}
+	}
+}
+
+func (r *discoveryRuleResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan discoveryRuleResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	zreq, d := expandDiscoveryRule(ctx, plan)
+	resp.Diagnostics.Append(d...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	id, err := r.client.DiscoveryRuleCreate(ctx, zreq)
+	if err != nil {
+		resp.Diagnostics.AddError("discoveryrule.create error", err.Error())
+		return
+	}
+
+	// Read back the resolved interface and the lifetime defaults.
+	rule, err := r.client.DiscoveryRuleGetByID(ctx, id)
+	if err != nil {
+		resp.Diagnostics.AddError("discoveryrule.get error", err.Error())
+		return
+	}
+	plan.ID = types.StringValue(id)
+	plan.InterfaceID = types.StringValue(rule.InterfaceID)
+	flattenDiscoveryRuleLifetime(rule, &plan)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *discoveryRuleResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state discoveryRuleResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	rule, err := r.client.DiscoveryRuleGetByID(ctx, state.ID.ValueString())
+	if err != nil {
+		if zabbix.IsNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError("discoveryrule.get error", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(flattenDiscoveryRule(ctx, rule, &state)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *discoveryRuleResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan discoveryRuleResourceModel
+	var state discoveryRuleResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	zreq, d := expandDiscoveryRule(ctx, plan)
+	resp.Diagnostics.Append(d...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if err := r.client.DiscoveryRuleUpdate(ctx, state.ID.ValueString(), zreq); err != nil {
+		resp.Diagnostics.AddError("discoveryrule.update error", err.Error())
+		return
+	}
+
+	plan.ID = state.ID
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *discoveryRuleResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state discoveryRuleResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	err := r.client.DiscoveryRuleDelete(ctx, state.ID.ValueString())
+	if err != nil && !zabbix.IsNotFound(err) {
+		resp.Diagnostics.AddError("discoveryrule.delete error", err.Error())
+	}
+}
+
+func (r *discoveryRuleResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+// expandDiscoveryRule builds the discoveryrule.create/update request from the plan.
+func expandDiscoveryRule(ctx context.Context, plan discoveryRuleResourceModel) (zabbix.DiscoveryRuleCreateRequest, diag.Diagnostics) {
+	source, diags := expandItemSource(ctx, plan.itemSourceModel)
+	req := zabbix.DiscoveryRuleCreateRequest{
+		ItemCreateRequest:   source,
+		Lifetime:            nullableString(plan.Lifetime),
+		LifetimeType:        nameToCode(lldLifetimeTypes, nullableString(plan.LifetimeType)),
+		EnabledLifetimeType: nameToCode(lldLifetimeTypes, nullableString(plan.EnabledLifetimeType)),
+		EnabledLifetime:     nullableString(plan.EnabledLifetime),
+		Filter:              expandLLDFilter(plan.EvalType, plan.Formula, plan.Conditions),
+	}
+
+	if !plan.LLDMacroPaths.IsNull() && !plan.LLDMacroPaths.IsUnknown() {
+		var paths map[string]string
+		diags.Append(plan.LLDMacroPaths.ElementsAs(ctx, &paths, false)...)
+		macros := make([]string, 0, len(paths))
+		for macro := range paths {
+			macros = append(macros, macro)
+		}
+		slices.Sort(macros)
+		for _, macro := range macros {
+			req.LLDMacroPaths = append(req.LLDMacroPaths, zabbix.LLDMacroPath{LLDMacro: macro, Path: paths[macro]})
+		}
+	}
+
+	for _, o := range plan.Overrides {
+		override := zabbix.LLDOverride{
+			Name:   o.Name.ValueString(),
+			Filter: expandLLDFilter(o.EvalType, o.Formula, o.Conditions),
+		}
+		if o.Stop.ValueBool() {
+			override.Stop = 1
+		}
+		for _, op := range o.Operations {
+			object, _ := strconv.Atoi(nameToCode(lldOperationObjects, op.Object.ValueString()))
+			operator, _ := strconv.Atoi(nameToCode(lldOperationOperators, op.Operator.ValueString()))
+			tags, d := mapToTags(ctx, op.Tags)
+			diags.Append(d...)
+			templateIDs, d := setToStringsOptional(ctx, op.TemplateIDs)
+			diags.Append(d...)
+			operation := zabbix.LLDOverrideOperation{
+				OperationObject: object,
+				Operator:        operator,
+				Value:           op.Value.ValueString(),
+				Delay:           nullableString(op.Delay),
+				History:         nullableString(op.History),
+				Trends:          nullableString(op.Trends),
+				Severity:        nullableString(op.Severity),
+				Tags:            tags,
+				TemplateIDs:     templateIDs,
+			}
+			// Status and discover flags: 0 enabled/discover, 1 disabled/do not discover.
+			if !op.Enabled.IsNull() {
+				operation.Status = lldOperationFlag(op.Enabled.ValueBool())
+			}
+			if !op.Discover.IsNull() {
+				operation.Discover = lldOperationFlag(op.Discover.ValueBool())
+			}
+			if mode := nameToCode(lldInventoryModes, nullableString(op.InventoryMode)); mode != "" {
+				i, _ := strconv.Atoi(mode)
+				operation.InventoryMode = strconv.Itoa(i - 1)
+			}
+			override.Operations = append(override.Operations, operation)
+		}
+		req.Overrides = append(req.Overrides, override)
+	}
+	return req, diags
+}
+
+func lldOperationFlag(value bool) string {
+	if value {
+		return "0"
+	}
+	return "1"
+}
+
+// expandLLDFilter converts a filter; with a custom formula, conditions are labelled in block order.
+func expandLLDFilter(evalType, formula types.String, conditions []lldConditionModel) zabbix.LLDFilter {
+	code, _ := strconv.Atoi(nameToCode(actionEvalTypes, evalType.ValueString()))
+	filter := zabbix.LLDFilter{EvalType: zabbix.FlexInt(code)}
+	if code == zabbix.ActionEvalTypeCustom {
+		filter.Formula = formula.ValueString()
+	}
+	for i, c := range conditions {
+		operator, _ := strconv.Atoi(nameToCode(lldConditionOperators, c.Operator.ValueString()))
+		condition := zabbix.LLDCondition{
+			Macro:    c.Macro.ValueString(),
+			Value:    c.Value.ValueString(),
+			Operator: zabbix.FlexInt(operator),
+		}
+		if code == zabbix.ActionEvalTypeCustom {
+			condition.FormulaID = actionFormulaID(i)
+		}
+		filter.Conditions = append(filter.Conditions, condition)
+	}
+	return filter
+}
+
+// flattenDiscoveryRule copies a discovery rule read from Zabbix into the model.
+func flattenDiscoveryRule(ctx context.Context, rule *zabbix.DiscoveryRule, state *discoveryRuleResourceModel) diag.Diagnostics {
+	diags := flattenItemSource(ctx, &rule.Item, &state.itemSourceModel)
+	flattenDiscoveryRuleLifetime(rule, state)
+	state.EvalType, state.Formula, state.Conditions = flattenLLDFilter(rule.Filter, state.Conditions)
+
+	if len(rule.LLDMacroPaths) > 0 || !state.LLDMacroPaths.IsNull() {
+		paths := make(map[string]string, len(rule.LLDMacroPaths))
+		for _, p := range rule.LLDMacroPaths {
+			paths[p.LLDMacro] = p.Path
+		}
+		value, d := types.MapValueFrom(ctx, types.StringType, paths)
+		diags.Append(d...)
+		state.LLDMacroPaths = value
+	}
+
+	overrides := slices.Clone(rule.Overrides)
+	slices.SortStableFunc(overrides, func(a, b zabbix.LLDOverride) int { return int(a.Step) - int(b.Step) })
+	previous := state.Overrides
+	state.Overrides = make([]lldOverrideModel, 0, len(overrides))
+	for i, o := range overrides {
+		var previousOverride lldOverrideModel
+		if i < len(previous) {
+			previousOverride = previous[i]
+		}
+		override := lldOverrideModel{
+			Name:       types.StringValue(o.Name),
+			Stop:       types.BoolValue(o.Stop == 1),
+			Operations: make([]lldOperationModel, 0, len(o.Operations)),
+		}
+		override.EvalType, override.Formula, override.Conditions = flattenLLDFilter(o.Filter, previousOverride.Conditions)
+		for j, op := range o.Operations {
+			var previousOperation lldOperationModel
+			if j < len(previousOverride.Operations) {
+				previousOperation = previousOverride.Operations[j]
+			}
+			operation, d := flattenLLDOperation(ctx, op, previousOperation)
+			diags.Append(d...)
+			override.Operations = append(override.Operations, operation)
+		}
+		state.Overrides = append(state.Overrides, override)
+	}
+	return diags
+}
+
+// flattenDiscoveryRuleLifetime reads the lifetime settings; the 7.0 settings are null on older versions.
+func flattenDiscoveryRuleLifetime(rule *zabbix.DiscoveryRule, state *discoveryRuleResourceModel) {
+	state.Lifetime = nullOrString(rule.Lifetime)
+	state.LifetimeType = nullOrString(codeToName(lldLifetimeTypes, rule.LifetimeType, ""))
+	state.EnabledLifetimeType = nullOrString(codeToName(lldLifetimeTypes, rule.EnabledLifetimeType, ""))
+	state.EnabledLifetime = nullOrString(rule.EnabledLifetime)
+}
+
+// flattenLLDFilter reads a filter. Conditions of a custom formula are ordered by label; otherwise the
+// configured order is kept when Zabbix returns the same conditions.
+func flattenLLDFilter(filter zabbix.LLDFilter, previous []lldConditionModel) (types.String, types.String, []lldConditionModel) {
+	evalType := types.StringValue(codeToName(actionEvalTypes, strconv.Itoa(int(filter.EvalType)), "and_or"))
+	formula := types.StringNull()
+	conds := slices.Clone(filter.Conditions)
+	if filter.EvalType == zabbix.ActionEvalTypeCustom {
+		formula = types.StringValue(filter.Formula)
+		slices.SortStableFunc(conds, func(a, b zabbix.LLDCondition) int {
+			if len(a.FormulaID) != len(b.FormulaID) {
+				return len(a.FormulaID) - len(b.FormulaID)
+			}
+			return strings.Compare(a.FormulaID, b.FormulaID)
+		})
+	}
+	conditions := make([]lldConditionModel, 0, len(conds))
+	for _, c := range conds {
+		conditions = append(conditions, lldConditionModel{
+			Macro:    types.StringValue(c.Macro),
+			Operator: types.StringValue(codeToName(lldConditionOperators, strconv.Itoa(int(c.Operator)), "matches")),
+			Value:    types.StringValue(c.Value),
+		})
+	}
+	if filter.EvalType != zabbix.ActionEvalTypeCustom && len(previous) == len(conditions) {
+		remaining := slices.Clone(conditions)
+		for _, p := range previous {
+			i := slices.IndexFunc(remaining, func(c lldConditionModel) bool {
+				return c.Macro.Equal(p.Macro) && c.Operator.Equal(p.Operator) && c.Value.Equal(p.Value)
+			})
+			if i < 0 {
+				return evalType, formula, conditions
+			}
+			remaining = slices.Delete(remaining, i, i+1)
+		}
+		conditions = slices.Clone(previous)
+	}
+	return evalType, formula, conditions
+}
+
+// flattenLLDOperation reads an override operation. Empty tags and templates stay null unless set in state.
+func flattenLLDOperation(ctx context.Context, op zabbix.LLDOverrideOperation, previous lldOperationModel) (lldOperationModel, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	operation := lldOperationModel{
+		Object:        types.StringValue(codeToName(lldOperationObjects, strconv.Itoa(op.OperationObject), "item_prototype")),
+		Operator:      types.StringValue(codeToName(lldOperationOperators, strconv.Itoa(op.Operator), "equals")),
+		Value:         types.StringValue(op.Value),
+		Enabled:       types.BoolNull(),
+		Discover:      types.BoolNull(),
+		Delay:         nullOrString(op.Delay),
+		History:       nullOrString(op.History),
+		Trends:        nullOrString(op.Trends),
+		Severity:      nullOrString(op.Severity),
+		Tags:          types.MapNull(types.StringType),
+		TemplateIDs:   stringsToSetOrNull(ctx, op.TemplateIDs),
+		InventoryMode: types.StringNull(),
+	}
+	if op.Status != "" {
+		operation.Enabled = types.BoolValue(zabbix.StatusToEnabled(op.Status))
+	}
+	if op.Discover != "" {
+		operation.Discover = types.BoolValue(zabbix.StatusToEnabled(op.Discover))
+	}
+	if len(op.Tags) > 0 || !previous.Tags.IsNull() {
+		tags, d := tagsToMap(ctx, op.Tags)
+		diags.Append(d...)
+		operation.Tags = tags
+	}
+	if len(op.TemplateIDs) == 0 && !previous.TemplateIDs.IsNull() {
+		operation.TemplateIDs = previous.TemplateIDs
+	}
+	if mode, err := strconv.Atoi(op.InventoryMode); err == nil {
+		operation.InventoryMode = nullOrString(codeToName(lldInventoryModes, strconv.Itoa(mode+1), ""))
+	}
+	return operation, diags
+}
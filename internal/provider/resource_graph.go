@@ -0,0 +1,515 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/rushiii/terraform-provider-zabbix/internal/zabbix"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/float64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ resource.Resource                   = &graphResource{}
+	_ resource.ResourceWithConfigure      = &graphResource{}
+	_ resource.ResourceWithImportState    = &graphResource{}
+	_ resource.ResourceWithValidateConfig = &graphResource{}
+)
+
+type graphResource struct {
+	client *zabbix.Client
+}
+
+func NewGraphResource() resource.Resource {
+	return &graphResource{}
+}
+
+func (r *graphResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_graph"
+}
+
+func (r *graphResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Zabbix graph resource: a custom graph of items (`zabbix_item`) of a host or template.",
+		Attributes:          graphAttributes(),
+		Blocks:              graphBlocks(),
+	}
+}
+
+func (r *graphResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	providerData, ok := req.ProviderData.(*providerData)
+	if !ok || providerData.Client == nil {
+		resp.Diagnostics.AddError("Invalid provider", "Zabbix client unavailable.")
+		return
+	}
+	r.client = providerData.Client
+}
+
+func (r *graphResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config graphModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	validateGraph(config, &resp.Diagnostics)
+}
+
+func (r *graphResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan graphModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	id, err := r.client.GraphCreate(ctx, expandGraph(plan))
+	if err != nil {
+		resp.Diagnostics.AddError("graph.create error", err.Error())
+		return
+	}
+
+	plan.ID = types.StringValue(id)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *graphResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state graphModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	graph, err := r.client.GraphGetByID(ctx, state.ID.ValueString())
+	if err != nil {
+		if zabbix.IsNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError("graph.get error", err.Error())
+		return
+	}
+
+	flattenGraph(graph, &state)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *graphResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan graphModel
+	var state graphModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.client.GraphUpdate(ctx, state.ID.ValueString(), expandGraph(plan)); err != nil {
+		resp.Diagnostics.AddError("graph.update error", err.Error())
+		return
+	}
+
+	plan.ID = state.ID
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *graphResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state graphModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	err := r.client.GraphDelete(ctx, state.ID.ValueString())
+	if err != nil && !zabbix.IsNotFound(err) {
+		resp.Diagnostics.AddError("graph.delete error", err.Error())
+	}
+}
+
+func (r *graphResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+// Enums of graphs, indexed by Zabbix code.
+var (
+	graphTypes         = []string{"normal", "stacked", "pie", "exploded"}
+	graphYAxisTypes    = []string{"calculated", "fixed", "item"}
+	graphCalcFunctions = []string{1: "min", 2: "avg", 4: "max", 7: "all", 9: "last"}
+	graphDrawTypes     = []string{"line", "filled_region", "bold_line", "dot", "dashed_line", "gradient_line"}
+	graphItemTypes     = []string{0: "simple", 2: "graph_sum"}
+	graphYAxisSides    = []string{"left", "right"}
+)
+
+var graphColor = regexp.MustCompile(`^[0-9A-Fa-f]{6}$`)
+
+// graphModel holds the attributes shared by zabbix_graph and zabbix_graph_prototype.
+type graphModel struct {
+	ID             types.String     `tfsdk:"id"`
+	Name           types.String     `tfsdk:"name"`
+	Width          types.Int64      `tfsdk:"width"`
+	Height         types.Int64      `tfsdk:"height"`
+	Type           types.String     `tfsdk:"type"`
+	PercentLeft    types.Float64    `tfsdk:"percent_left"`
+	PercentRight   types.Float64    `tfsdk:"percent_right"`
+	Show3D         types.Bool       `tfsdk:"show_3d"`
+	ShowLegend     types.Bool       `tfsdk:"show_legend"`
+	ShowWorkPeriod types.Bool       `tfsdk:"show_work_period"`
+	ShowTriggers   types.Bool       `tfsdk:"show_triggers"`
+	YMinType       types.String     `tfsdk:"ymin_type"`
+	YMaxType       types.String     `tfsdk:"ymax_type"`
+	YAxisMin       types.Float64    `tfsdk:"yaxis_min"`
+	YAxisMax       types.Float64    `tfsdk:"yaxis_max"`
+	YMinItemID     types.String     `tfsdk:"ymin_item_id"`
+	YMaxItemID     types.String     `tfsdk:"ymax_item_id"`
+	Items          []graphItemModel `tfsdk:"item"`
+}
+
+type graphItemModel struct {
+	ItemID       types.String `tfsdk:"item_id"`
+	Color        types.String `tfsdk:"color"`
+	CalcFunction types.String `tfsdk:"calc_function"`
+	DrawType     types.String `tfsdk:"draw_type"`
+	YAxisSide    types.String `tfsdk:"yaxis_side"`
+	Type         types.String `tfsdk:"type"`
+}
+
+func graphAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"id": schema.StringAttribute{
+			Computed: true,
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.UseStateForUnknown(),
+			},
+		},
+		"name": schema.StringAttribute{
+			Required:            true,
+			MarkdownDescription: "Graph name.",
+		},
+		"width": schema.Int64Attribute{
+			Optional:            true,
+			Computed:            true,
+			Default:             int64default.StaticInt64(900),
+			MarkdownDescription: "Width in pixels (20-65535). Default: 900.",
+		},
+		"height": schema.Int64Attribute{
+			Optional:            true,
+			Computed:            true,
+			Default:             int64default.StaticInt64(200),
+			MarkdownDescription: "Height in pixels (20-65535). Default: 200.",
+		},
+		"type": schema.StringAttribute{
+			Optional:            true,
+			Computed:            true,
+			Default:             stringdefault.StaticString("normal"),
+			MarkdownDescription: "Graph type: `normal`, `stacked`, `pie` or `exploded`. Default: `normal`.",
+			Validators: []validator.String{
+				stringOneOf(graphTypes...),
+			},
+		},
+		"percent_left": schema.Float64Attribute{
+			Optional:            true,
+			Computed:            true,
+			Default:             float64default.StaticFloat64(0),
+			MarkdownDescription: "Percentile line of the left y axis (0-100, 0 for none; normal graphs). Default: 0.",
+		},
+		"percent_right": schema.Float64Attribute{
+			Optional:            true,
+			Computed:            true,
+			Default:             float64default.StaticFloat64(0),
+			MarkdownDescription: "Percentile line of the right y axis (0-100, 0 for none; normal graphs). Default: 0.",
+		},
+		"show_3d": schema.BoolAttribute{
+			Optional:            true,
+			Computed:            true,
+			Default:             booldefault.StaticBool(false),
+			MarkdownDescription: "Draw a 3D graph (pie and exploded graphs). Default: false.",
+		},
+		"show_legend": schema.BoolAttribute{
+			Optional:            true,
+			Computed:            true,
+			Default:             booldefault.StaticBool(true),
+			MarkdownDescription: "Show the legend. Default: true.",
+		},
+		"show_work_period": schema.BoolAttribute{
+			Optional:            true,
+			Computed:            true,
+			Default:             booldefault.StaticBool(true),
+			MarkdownDescription: "Highlight the working time. Default: true.",
+		},
+		"show_triggers": schema.BoolAttribute{
+			Optional:            true,
+			Computed:            true,
+			Default:             booldefault.StaticBool(true),
+			MarkdownDescription: "Show the thresholds of simple triggers. Default: true.",
+		},
+		"ymin_type": schema.StringAttribute{
+			Optional:            true,
+			Computed:            true,
+			Default:             stringdefault.StaticString("calculated"),
+			MarkdownDescription: "Y axis minimum: `calculated`, `fixed` (`yaxis_min`) or `item` (last value of `ymin_item_id`). Default: `calculated`.",
+			Validators: []validator.String{
+				stringOneOf(graphYAxisTypes...),
+			},
+		},
+		"ymax_type": schema.StringAttribute{
+			Optional:            true,
+			Computed:            true,
+			Default:             stringdefault.StaticString("calculated"),
+			MarkdownDescription: "Y axis maximum: `calculated`, `fixed` (`yaxis_max`) or `item` (last value of `ymax_item_id`). Default: `calculated`.",
+			Validators: []validator.String{
+				stringOneOf(graphYAxisTypes...),
+			},
+		},
+		"yaxis_min": schema.Float64Attribute{
+			Optional:            true,
+			MarkdownDescription: "Fixed y axis minimum, with `ymin_type = \"fixed\"`.",
+		},
+		"yaxis_max": schema.Float64Attribute{
+			Optional:            true,
+			MarkdownDescription: "Fixed y axis maximum, with `ymax_type = \"fixed\"`.",
+		},
+		"ymin_item_id": schema.StringAttribute{
+			Optional:            true,
+			MarkdownDescription: "Item giving the y axis minimum, with `ymin_type = \"item\"`.",
+		},
+		"ymax_item_id": schema.StringAttribute{
+			Optional:            true,
+			MarkdownDescription: "Item giving the y axis maximum, with `ymax_type = \"item\"`.",
+		},
+	}
+}
+
+func graphBlocks() map[string]schema.Block {
+	return map[string]schema.Block{
+		"item": schema.ListNestedBlock{
+			MarkdownDescription: "Graph item, drawn in block order.",
+			NestedObject: schema.NestedBlockObject{
+				Attributes: map[string]schema.Attribute{
+					"item_id": schema.StringAttribute{
+						Required:            true,
+						MarkdownDescription: "Item ID (`zabbix_item`).",
+					},
+					"color": schema.StringAttribute{
+						Required:            true,
+						MarkdownDescription: "Color as six hex digits, e.g. `1A7C11`.",
+					},
+					"calc_function": schema.StringAttribute{
+						Optional:            true,
+						Computed:            true,
+						Default:             stringdefault.StaticString("avg"),
+						MarkdownDescription: "Value drawn when several values fall in a pixel: `min`, `avg`, `max`, `all` or `last` (pie graphs). Default: `avg`.",
+						Validators: []validator.String{
+							stringOneOf("min", "avg", "max", "all", "last"),
+						},
+					},
+					"draw_type": schema.StringAttribute{
+						Optional:            true,
+						Computed:            true,
+						Default:             stringdefault.StaticString("line"),
+						MarkdownDescription: "Draw style: `line`, `filled_region`, `bold_line`, `dot`, `dashed_line` or `gradient_line`. Default: `line`.",
+						Validators: []validator.String{
+							stringOneOf(graphDrawTypes...),
+						},
+					},
+					"yaxis_side": schema.StringAttribute{
+						Optional:            true,
+						Computed:            true,
+						Default:             stringdefault.StaticString("left"),
+						MarkdownDescription: "Y axis of the item: `left` or `right`. Default: `left`.",
+						Validators: []validator.String{
+							stringOneOf(graphYAxisSides...),
+						},
+					},
+					"type": schema.StringAttribute{
+						Optional:            true,
+						Computed:            true,
+						Default:             stringdefault.StaticString("simple"),
+						MarkdownDescription: "`simple`, or `graph_sum` for the item giving the whole of a pie graph. Default: `simple`.",
+						Validators: []validator.String{
+							stringOneOf("simple", "graph_sum"),
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// validateGraph checks the attribute combinations of graphs and graph prototypes.
+func validateGraph(config graphModel, diags *diag.Diagnostics) {
+	if len(config.Items) == 0 {
+		diags.AddAttributeError(path.Root("item"), "Missing attribute", "Graphs have at least one `item` block.")
+	}
+	for _, size := range []struct {
+		name  string
+		value types.Int64
+	}{{"width", config.Width}, {"height", config.Height}} {
+		if !size.value.IsNull() && !size.value.IsUnknown() && (size.value.ValueInt64() < 20 || size.value.ValueInt64() > 65535) {
+			diags.AddAttributeError(path.Root(size.name), "Invalid attribute value", fmt.Sprintf("`%s` is between 20 and 65535 pixels.", size.name))
+		}
+	}
+
+	graphType := config.Type.ValueString()
+	known := !config.Type.IsUnknown()
+	pie := graphType == "pie" || graphType == "exploded"
+	for _, percent := range []struct {
+		name  string
+		value types.Float64
+	}{{"percent_left", config.PercentLeft}, {"percent_right", config.PercentRight}} {
+		if percent.value.IsNull() || percent.value.IsUnknown() {
+			continue
+		}
+		if v := percent.value.ValueFloat64(); v < 0 || v > 100 {
+			diags.AddAttributeError(path.Root(percent.name), "Invalid attribute value", fmt.Sprintf("`%s` is between 0 and 100.", percent.name))
+		} else if v > 0 && known && graphType != "normal" {
+			diags.AddAttributeError(path.Root(percent.name), "Invalid attribute combination", fmt.Sprintf("`%s` requires `type = \"normal\"`.", percent.name))
+		}
+	}
+	if config.Show3D.ValueBool() && known && !pie {
+		diags.AddAttributeError(path.Root("show_3d"), "Invalid attribute combination", "`show_3d` requires `type` `pie` or `exploded`.")
+	}
+
+	for _, axis := range []struct {
+		prefix string
+		kind   types.String
+		value  types.Float64
+		itemID types.String
+	}{{"ymin", config.YMinType, config.YAxisMin, config.YMinItemID}, {"ymax", config.YMaxType, config.YAxisMax, config.YMaxItemID}} {
+		if axis.kind.IsUnknown() {
+			continue
+		}
+		valueName := "yaxis_" + strings.TrimPrefix(axis.prefix, "y")
+		itemName := axis.prefix + "_item_id"
+		kind := axis.kind.ValueString()
+		switch {
+		case kind == "fixed" && axis.value.IsNull():
+			diags.AddAttributeError(path.Root(valueName), "Missing attribute", fmt.Sprintf("`%s_type = \"fixed\"` requires `%s`.", axis.prefix, valueName))
+		case kind != "fixed" && !axis.value.IsNull():
+			diags.AddAttributeError(path.Root(valueName), "Invalid attribute combination", fmt.Sprintf("`%s` requires `%s_type = \"fixed\"`.", valueName, axis.prefix))
+		}
+		switch {
+		case kind == "item" && axis.itemID.IsNull():
+			diags.AddAttributeError(path.Root(itemName), "Missing attribute", fmt.Sprintf("`%s_type = \"item\"` requires `%s`.", axis.prefix, itemName))
+		case kind != "item" && !axis.itemID.IsNull():
+			diags.AddAttributeError(path.Root(itemName), "Invalid attribute combination", fmt.Sprintf("`%s` requires `%s_type = \"item\"`.", itemName, axis.prefix))
+		}
+		if kind != "calculated" && known && pie {
+			diags.AddAttributeError(path.Root(axis.prefix+"_type"), "Invalid attribute combination", "Pie and exploded graphs have no y axis.")
+		}
+	}
+
+	for i, item := range config.Items {
+		itemPath := path.Root("item").AtListIndex(i)
+		if !item.Color.IsUnknown() && !graphColor.MatchString(item.Color.ValueString()) {
+			diags.AddAttributeError(itemPath.AtName("color"), "Invalid attribute value", fmt.Sprintf("%q is not a color of six hex digits, e.g. `1A7C11`.", item.Color.ValueString()))
+		}
+		if item.Type.ValueString() == "graph_sum" && known && !pie {
+			diags.AddAttributeError(itemPath.AtName("type"), "Invalid attribute combination", "`graph_sum` items require `type` `pie` or `exploded`.")
+		}
+	}
+}
+
+// expandGraph builds the graph.create/update request from the plan.
+func expandGraph(plan graphModel) zabbix.GraphCreateRequest {
+	req := zabbix.GraphCreateRequest{
+		Name:           plan.Name.ValueString(),
+		Width:          int(plan.Width.ValueInt64()),
+		Height:         int(plan.Height.ValueInt64()),
+		GraphType:      int(atoi64(nameToCode(graphTypes, plan.Type.ValueString()))),
+		PercentLeft:    plan.PercentLeft.ValueFloat64(),
+		PercentRight:   plan.PercentRight.ValueFloat64(),
+		Show3D:         plan.Show3D.ValueBool(),
+		ShowLegend:     plan.ShowLegend.ValueBool(),
+		ShowWorkPeriod: plan.ShowWorkPeriod.ValueBool(),
+		ShowTriggers:   plan.ShowTriggers.ValueBool(),
+		YMinType:       int(atoi64(nameToCode(graphYAxisTypes, plan.YMinType.ValueString()))),
+		YMaxType:       int(atoi64(nameToCode(graphYAxisTypes, plan.YMaxType.ValueString()))),
+		YAxisMin:       plan.YAxisMin.ValueFloat64(),
+		YAxisMax:       plan.YAxisMax.ValueFloat64(),
+		YMinItemID:     plan.YMinItemID.ValueString(),
+		YMaxItemID:     plan.YMaxItemID.ValueString(),
+		Items:          make([]zabbix.GraphItem, 0, len(plan.Items)),
+	}
+	for _, item := range plan.Items {
+		req.Items = append(req.Items, zabbix.GraphItem{
+			ItemID:    item.ItemID.ValueString(),
+			Color:     strings.ToUpper(item.Color.ValueString()),
+			CalcFnc:   zabbix.FlexInt(atoi64(nameToCode(graphCalcFunctions, item.CalcFunction.ValueString()))),
+			DrawType:  zabbix.FlexInt(atoi64(nameToCode(graphDrawTypes, item.DrawType.ValueString()))),
+			Type:      zabbix.FlexInt(atoi64(nameToCode(graphItemTypes, item.Type.ValueString()))),
+			YAxisSide: zabbix.FlexInt(atoi64(nameToCode(graphYAxisSides, item.YAxisSide.ValueString()))),
+		})
+	}
+	return req
+}
+
+// flattenGraph writes a graph read from the API into state. Item colors keep the case of the configuration.
+func flattenGraph(graph *zabbix.Graph, state *graphModel) {
+	state.Name = types.StringValue(graph.Name)
+	state.Width = types.Int64Value(int64(graph.Width))
+	state.Height = types.Int64Value(int64(graph.Height))
+	state.Type = types.StringValue(codeToName(graphTypes, strconv.Itoa(int(graph.GraphType)), "normal"))
+	state.PercentLeft = types.Float64Value(parseGraphFloat(graph.PercentLeft))
+	state.PercentRight = types.Float64Value(parseGraphFloat(graph.PercentRight))
+	state.Show3D = types.BoolValue(graph.Show3D == "1")
+	state.ShowLegend = types.BoolValue(graph.ShowLegend == "1")
+	state.ShowWorkPeriod = types.BoolValue(graph.ShowWorkPeriod == "1")
+	state.ShowTriggers = types.BoolValue(graph.ShowTriggers == "1")
+	state.YMinType = types.StringValue(codeToName(graphYAxisTypes, strconv.Itoa(int(graph.YMinType)), "calculated"))
+	state.YMaxType = types.StringValue(codeToName(graphYAxisTypes, strconv.Itoa(int(graph.YMaxType)), "calculated"))
+	state.YAxisMin = types.Float64Null()
+	if graph.YMinType == zabbix.GraphYAxisFixed {
+		state.YAxisMin = types.Float64Value(parseGraphFloat(graph.YAxisMin))
+	}
+	state.YAxisMax = types.Float64Null()
+	if graph.YMaxType == zabbix.GraphYAxisFixed {
+		state.YAxisMax = types.Float64Value(parseGraphFloat(graph.YAxisMax))
+	}
+	state.YMinItemID = types.StringNull()
+	if graph.YMinType == zabbix.GraphYAxisItem {
+		state.YMinItemID = types.StringValue(graph.YMinItemID)
+	}
+	state.YMaxItemID = types.StringNull()
+	if graph.YMaxType == zabbix.GraphYAxisItem {
+		state.YMaxItemID = types.StringValue(graph.YMaxItemID)
+	}
+
+	gitems := append([]zabbix.GraphItem(nil), graph.Items...)
+	sort.SliceStable(gitems, func(i, j int) bool { return gitems[i].SortOrder < gitems[j].SortOrder })
+	items := make([]graphItemModel, 0, len(gitems))
+	for i, gitem := range gitems {
+		color := types.StringValue(gitem.Color)
+		if i < len(state.Items) && strings.EqualFold(state.Items[i].Color.ValueString(), gitem.Color) {
+			color = state.Items[i].Color
+		}
+		items = append(items, graphItemModel{
+			ItemID:       types.StringValue(gitem.ItemID),
+			Color:        color,
+			CalcFunction: types.StringValue(codeToName(graphCalcFunctions, strconv.Itoa(int(gitem.CalcFnc)), "avg")),
+			DrawType:     types.StringValue(codeToName(graphDrawTypes, strconv.Itoa(int(gitem.DrawType)), "line")),
+			YAxisSide:    types.StringValue(codeToName(graphYAxisSides, strconv.Itoa(int(gitem.YAxisSide)), "left")),
+			Type:         types.StringValue(codeToName(graphItemTypes, strconv.Itoa(int(gitem.Type)), "simple")),
+		})
+	}
+	state.Items = items
+}
+
+// parseGraphFloat parses the decimal strings of graph.get ("0.0000").
+func parseGraphFloat(value string) float64 {
+	f, _ := strconv.ParseFloat(value, 64)
+	return f
+}
@@ -0,0 +1,166 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/rushiii/terraform-provider-zabbix/internal/zabbix"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ resource.Resource                   = &graphPrototypeResource{}
+	_ resource.ResourceWithConfigure      = &graphPrototypeResource{}
+	_ resource.ResourceWithImportState    = &graphPrototypeResource{}
+	_ resource.ResourceWithValidateConfig = &graphPrototypeResource{}
+)
+
+type graphPrototypeResource struct {
+	client *zabbix.Client
+}
+
+type graphPrototypeResourceModel struct {
+	graphModel
+	RuleID   types.String `tfsdk:"rule_id"`
+	Discover types.Bool   `tfsdk:"discover"`
+}
+
+func NewGraphPrototypeResource() resource.Resource {
+	return &graphPrototypeResource{}
+}
+
+func (r *graphPrototypeResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_graph_prototype"
+}
+
+func (r *graphPrototypeResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	attributes := prototypeAttributes(graphAttributes())
+	attributes["name"] = schema.StringAttribute{
+		Required:            true,
+		MarkdownDescription: "Graph prototype name, usually with LLD macros (e.g. `Traffic on {#IFNAME}`).",
+	}
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Zabbix graph prototype resource: graphs created by a discovery rule for each discovered entity. Items are item prototypes of the rule (`zabbix_item_prototype`), optionally with plain items of the same host.",
+		Attributes:          attributes,
+		Blocks:              graphBlocks(),
+	}
+}
+
+func (r *graphPrototypeResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	providerData, ok := req.ProviderData.(*providerData)
+	if !ok || providerData.Client == nil {
+		resp.Diagnostics.AddError("Invalid provider", "Zabbix client unavailable.")
+		return
+	}
+	r.client = providerData.Client
+}
+
+func (r *graphPrototypeResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config graphPrototypeResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	validateGraph(config.graphModel, &resp.Diagnostics)
+}
+
+func (r *graphPrototypeResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan graphPrototypeResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	id, err := r.client.GraphPrototypeCreate(ctx, zabbix.GraphPrototypeCreateRequest{
+		GraphCreateRequest: expandGraph(plan.graphModel),
+		Discover:           plan.Discover.ValueBool(),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("graphprototype.create error", err.Error())
+		return
+	}
+
+	// The rule follows from the item prototypes of the graph: check it is the configured one.
+	prototype, err := r.client.GraphPrototypeGetByID(ctx, id)
+	if err != nil {
+		resp.Diagnostics.AddError("graphprototype.get error", err.Error())
+		return
+	}
+	if prototype.DiscoveryRule.ItemID != plan.RuleID.ValueString() {
+		_ = r.client.GraphPrototypeDelete(ctx, id)
+		resp.Diagnostics.AddAttributeError(path.Root("rule_id"), "Discovery rule mismatch",
+			fmt.Sprintf("The graph items are item prototypes of discovery rule %s, not %s.", prototype.DiscoveryRule.ItemID, plan.RuleID.ValueString()))
+		return
+	}
+
+	plan.ID = types.StringValue(id)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *graphPrototypeResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state graphPrototypeResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	prototype, err := r.client.GraphPrototypeGetByID(ctx, state.ID.ValueString())
+	if err != nil {
+		if zabbix.IsNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError("graphprototype.get error", err.Error())
+		return
+	}
+
+	flattenGraph(&prototype.Graph, &state.graphModel)
+	state.RuleID = types.StringValue(prototype.DiscoveryRule.ItemID)
+	state.Discover = types.BoolValue(zabbix.StatusToEnabled(prototype.Discover))
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *graphPrototypeResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan graphPrototypeResourceModel
+	var state graphPrototypeResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.GraphPrototypeUpdate(ctx, state.ID.ValueString(), zabbix.GraphPrototypeCreateRequest{
+		GraphCreateRequest: expandGraph(plan.graphModel),
+		Discover:           plan.Discover.ValueBool(),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("graphprototype.update error", err.Error())
+		return
+	}
+
+	plan.ID = state.ID
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *graphPrototypeResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state graphPrototypeResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	err := r.client.GraphPrototypeDelete(ctx, state.ID.ValueString())
+	if err != nil && !zabbix.IsNotFound(err) {
+		resp.Diagnostics.AddError("graphprototype.delete error", err.Error())
+	}
+}
+
+func (r *graphPrototypeResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
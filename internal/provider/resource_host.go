@@ -2,6 +2,7 @@ package provider
 
 import (
 	"context"
+	"fmt"
 	"strconv"
 
 	"github.com/rushiii/terraform-provider-zabbix/internal/zabbix"
@@ -15,16 +16,25 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
 var (
-	_ resource.Resource                = &hostResource{}
-	_ resource.ResourceWithConfigure   = &hostResource{}
-	_ resource.ResourceWithImportState = &hostResource{}
-	_ resource.ResourceWithModifyPlan  = &hostResource{}
+	_ resource.Resource                   = &hostResource{}
+	_ resource.ResourceWithConfigure      = &hostResource{}
+	_ resource.ResourceWithImportState    = &hostResource{}
+	_ resource.ResourceWithModifyPlan     = &hostResource{}
+	_ resource.ResourceWithValidateConfig = &hostResource{}
 )
 
+// hostMonitoredBy is indexed by the Zabbix monitored_by code.
+var hostMonitoredBy = []string{
+	zabbix.HostMonitoredByServer:     "server",
+	zabbix.HostMonitoredByProxy:      "proxy",
+	zabbix.HostMonitoredByProxyGroup: "proxy_group",
+}
+
 type hostResource struct {
 	client *zabbix.Client
 }
@@ -39,6 +49,18 @@ type hostResourceModel struct {
 	TemplateIDs    types.Set            `tfsdk:"template_ids"`
 	TemplateNames  types.Set            `tfsdk:"template_names"`
 	Tags           types.Map            `tfsdk:"tags"`
+	MonitoredBy    types.String         `tfsdk:"monitored_by"`
+	ProxyID        types.String         `tfsdk:"proxy_id"`
+	ProxyName      types.String         `tfsdk:"proxy_name"`
+	ProxyGroupID   types.String         `tfsdk:"proxy_group_id"`
+	TLSConnect     types.String         `tfsdk:"tls_connect"`
+	TLSAccept      types.Set            `tfsdk:"tls_accept"`
+	TLSPSKIdentity types.String         `tfsdk:"tls_psk_identity"`
+	TLSPSK         types.String         `tfsdk:"tls_psk"`
+	TLSPSKWO       types.String         `tfsdk:"tls_psk_wo"`
+	TLSPSKWOVer    types.Int64          `tfsdk:"tls_psk_wo_version"`
+	TLSIssuer      types.String         `tfsdk:"tls_issuer"`
+	TLSSubject     types.String         `tfsdk:"tls_subject"`
 	Interfaces     []hostInterfaceModel `tfsdk:"interfaces"`
 }
 
@@ -53,10 +75,33 @@ type hostInterfaceModel struct {
 }
 
 type hostSNMPDetailsModel struct {
-	Version   types.Int64  `tfsdk:"version"`
-	Community types.String `tfsdk:"community"`
+	Version        types.Int64  `tfsdk:"version"`
+	Bulk           types.Bool   `tfsdk:"bulk"`
+	MaxRepetitions types.Int64  `tfsdk:"max_repetitions"`
+	Community      types.String `tfsdk:"community"`
+	SecurityName   types.String `tfsdk:"security_name"`
+	SecurityLevel  types.String `tfsdk:"security_level"`
+	AuthProtocol   types.String `tfsdk:"auth_protocol"`
+	AuthPassphrase types.String `tfsdk:"auth_passphrase"`
+	PrivProtocol   types.String `tfsdk:"priv_protocol"`
+	PrivPassphrase types.String `tfsdk:"priv_passphrase"`
+	ContextName    types.String `tfsdk:"context_name"`
 }
 
+// SNMPv3 enums, indexed by Zabbix code.
+var (
+	snmpSecurityLevels = []string{
+		zabbix.SNMPSecurityNoAuthNoPriv: "no_auth_no_priv",
+		zabbix.SNMPSecurityAuthNoPriv:   "auth_no_priv",
+		zabbix.SNMPSecurityAuthPriv:     "auth_priv",
+	}
+	snmpAuthProtocols = []string{"md5", "sha1", "sha224", "sha256", "sha384", "sha512"}
+	snmpPrivProtocols = []string{"des", "aes128", "aes192", "aes256", "aes192c", "aes256c"}
+)
+
+// snmpDefaultCommunity is the community of SNMP interfaces without one.
+const snmpDefaultCommunity = "{$SNMP_COMMUNITY}"
+
 func NewHostResource() resource.Resource {
 	return &hostResource{}
 }
@@ -66,109 +111,212 @@ func (r *hostResource) Metadata(_ context.Context, req resource.MetadataRequest,
 }
 
 func (r *hostResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
-	resp.Schema = schema.Schema{
-		MarkdownDescription: "Zabbix host resource.",
-		Attributes: map[string]schema.Attribute{
-			"id": schema.StringAttribute{
-				Computed: true,
-				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.UseStateForUnknown(),
-				},
-				MarkdownDescription: "Internal Zabbix ID.",
-			},
-			"name": schema.StringAttribute{
-				Required:            true,
-				MarkdownDescription: "Host technical name (`host`).",
-			},
-			"visible_name": schema.StringAttribute{
-				Optional:            true,
-				MarkdownDescription: "Visible name (`name`) in Zabbix.",
-			},
-			"enabled": schema.BoolAttribute{
-				Optional:            true,
-				Computed:            true,
-				Default:             booldefault.StaticBool(true),
-				MarkdownDescription: "Whether the host is enabled.",
+	attributes := map[string]schema.Attribute{
+		"id": schema.StringAttribute{
+			Computed: true,
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.UseStateForUnknown(),
 			},
-			"host_group_ids": schema.SetAttribute{
-				Optional:            true,
-				Computed:            true,
-				ElementType:         types.StringType,
-				MarkdownDescription: "Host group IDs.",
-			},
-			"host_group_names": schema.SetAttribute{
-				Optional:            true,
-				Computed:            true,
-				ElementType:         types.StringType,
-				MarkdownDescription: "Host group names. Alternative to host_group_ids.",
-			},
-			"template_ids": schema.SetAttribute{
-				Optional:            true,
-				Computed:            true,
-				ElementType:         types.StringType,
-				MarkdownDescription: "Linked template IDs.",
-			},
-			"template_names": schema.SetAttribute{
-				Optional:            true,
-				Computed:            true,
-				ElementType:         types.StringType,
-				MarkdownDescription: "Template names. Alternative to template_ids.",
-			},
-			"tags": schema.MapAttribute{
-				Optional:            true,
-				ElementType:         types.StringType,
-				MarkdownDescription: "Tags map (tag => value).",
+			MarkdownDescription: "Internal Zabbix ID.",
+		},
+		"name": schema.StringAttribute{
+			Required:            true,
+			MarkdownDescription: "Host technical name (`host`).",
+		},
+		"visible_name": schema.StringAttribute{
+			Optional:            true,
+			MarkdownDescription: "Visible name (`name`) in Zabbix.",
+		},
+		"enabled": schema.BoolAttribute{
+			Optional:            true,
+			Computed:            true,
+			Default:             booldefault.StaticBool(true),
+			MarkdownDescription: "Whether the host is enabled.",
+		},
+		"host_group_ids": schema.SetAttribute{
+			Optional:            true,
+			Computed:            true,
+			ElementType:         types.StringType,
+			MarkdownDescription: "Host group IDs.",
+		},
+		"host_group_names": schema.SetAttribute{
+			Optional:            true,
+			Computed:            true,
+			ElementType:         types.StringType,
+			MarkdownDescription: "Host group names. Alternative to host_group_ids.",
+		},
+		"template_ids": schema.SetAttribute{
+			Optional:            true,
+			Computed:            true,
+			ElementType:         types.StringType,
+			MarkdownDescription: "Linked template IDs.",
+		},
+		"template_names": schema.SetAttribute{
+			Optional:            true,
+			Computed:            true,
+			ElementType:         types.StringType,
+			MarkdownDescription: "Template names. Alternative to template_ids.",
+		},
+		"tags": schema.MapAttribute{
+			Optional:            true,
+			ElementType:         types.StringType,
+			MarkdownDescription: "Tags map (tag => value).",
+		},
+		"monitored_by": schema.StringAttribute{
+			Optional:            true,
+			Computed:            true,
+			Default:             stringdefault.StaticString("server"),
+			MarkdownDescription: "`server`, `proxy` or `proxy_group` (Zabbix 7.0+). Default: `server`.",
+			Validators: []validator.String{
+				stringOneOf("server", "proxy", "proxy_group"),
 			},
 		},
+		"proxy_id": schema.StringAttribute{
+			Optional:            true,
+			Computed:            true,
+			MarkdownDescription: "ID of the proxy monitoring the host, with `monitored_by = \"proxy\"`.",
+		},
+		"proxy_name": schema.StringAttribute{
+			Optional:            true,
+			MarkdownDescription: "Name of the proxy monitoring the host. Alternative to proxy_id.",
+		},
+		"proxy_group_id": schema.StringAttribute{
+			Optional:            true,
+			MarkdownDescription: "ID of the proxy group monitoring the host, with `monitored_by = \"proxy_group\"`.",
+		},
+	}
+	for name, attribute := range tlsAttributes("agent") {
+		attributes[name] = attribute
+	}
+	attributes["tls_psk"] = schema.StringAttribute{
+		Optional:            true,
+		Sensitive:           true,
+		MarkdownDescription: "Pre-shared key, 32 to 512 hex digits. Stored in state; prefer `tls_psk_wo` on Terraform 1.11+. Conflicts with `tls_psk_wo`.",
+	}
+	attributes["tls_psk_wo"] = schema.StringAttribute{
+		Optional:            true,
+		Sensitive:           true,
+		WriteOnly:           true,
+		MarkdownDescription: "Write-only pre-shared key (Terraform 1.11+), never stored in state. Sent on create, and on update only when `tls_psk_wo_version` changes. Conflicts with `tls_psk`.",
+	}
+	attributes["tls_psk_wo_version"] = schema.Int64Attribute{
+		Optional:            true,
+		MarkdownDescription: "Change this value (e.g. increment it) to send `tls_psk_wo` again and rotate the key.",
+	}
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Zabbix host resource.",
+		Attributes:          attributes,
 		Blocks: map[string]schema.Block{
-			"interfaces": schema.ListNestedBlock{
-				MarkdownDescription: "Host interfaces.",
-				NestedObject: schema.NestedBlockObject{
+			"interfaces": hostInterfacesBlock("Host interfaces."),
+		},
+	}
+}
+
+// hostInterfacesBlock is the interfaces block of hosts and host prototypes.
+func hostInterfacesBlock(description string) schema.ListNestedBlock {
+	return schema.ListNestedBlock{
+		MarkdownDescription: description,
+		NestedObject: schema.NestedBlockObject{
+			Attributes: map[string]schema.Attribute{
+				"type": schema.Int64Attribute{
+					Required:            true,
+					MarkdownDescription: "1=Agent, 2=SNMP, 3=IPMI, 4=JMX.",
+				},
+				"main": schema.BoolAttribute{
+					Optional: true,
+					Computed: true,
+					Default:  booldefault.StaticBool(true),
+				},
+				"use_ip": schema.BoolAttribute{
+					Optional: true,
+					Computed: true,
+					Default:  booldefault.StaticBool(true),
+				},
+				"ip": schema.StringAttribute{
+					Optional: true,
+				},
+				"dns": schema.StringAttribute{
+					Optional: true,
+				},
+				"port": schema.StringAttribute{
+					Optional: true,
+					Computed: true,
+					Default:  stringdefault.StaticString("10050"),
+				},
+			},
+			Blocks: map[string]schema.Block{
+				"snmp_details": schema.SingleNestedBlock{
+					MarkdownDescription: "SNMP details. Used mainly with type=2.",
 					Attributes: map[string]schema.Attribute{
-						"type": schema.Int64Attribute{
-							Required:            true,
-							MarkdownDescription: "1=Agent, 2=SNMP, 3=IPMI, 4=JMX.",
+						"version": schema.Int64Attribute{
+							Optional:            true,
+							Computed:            true,
+							Default:             int64default.StaticInt64(2),
+							MarkdownDescription: "SNMP version: 1, 2 (v2c) or 3.",
+						},
+						"bulk": schema.BoolAttribute{
+							Optional:            true,
+							Computed:            true,
+							Default:             booldefault.StaticBool(true),
+							MarkdownDescription: "Use bulk requests.",
 						},
-						"main": schema.BoolAttribute{
-							Optional: true,
-							Computed: true,
-							Default:  booldefault.StaticBool(true),
+						"max_repetitions": schema.Int64Attribute{
+							Optional:            true,
+							Computed:            true,
+							Default:             int64default.StaticInt64(10),
+							MarkdownDescription: "Max repetitions of bulk walk requests (1-100, Zabbix 7.0+; ignored before).",
 						},
-						"use_ip": schema.BoolAttribute{
-							Optional: true,
-							Computed: true,
-							Default:  booldefault.StaticBool(true),
+						"community": schema.StringAttribute{
+							Optional:            true,
+							Computed:            true,
+							Default:             stringdefault.StaticString(snmpDefaultCommunity),
+							MarkdownDescription: "SNMP v1/v2c community.",
 						},
-						"ip": schema.StringAttribute{
-							Optional: true,
+						"security_name": schema.StringAttribute{
+							Optional:            true,
+							MarkdownDescription: "SNMPv3 security name.",
 						},
-						"dns": schema.StringAttribute{
-							Optional: true,
+						"security_level": schema.StringAttribute{
+							Optional:            true,
+							Computed:            true,
+							Default:             stringdefault.StaticString("no_auth_no_priv"),
+							MarkdownDescription: "SNMPv3 security level: `no_auth_no_priv`, `auth_no_priv` or `auth_priv`.",
+							Validators: []validator.String{
+								stringOneOf("no_auth_no_priv", "auth_no_priv", "auth_priv"),
+							},
 						},
-						"port": schema.StringAttribute{
-							Optional: true,
-							Computed: true,
-							Default:  stringdefault.StaticString("10050"),
+						"auth_protocol": schema.StringAttribute{
+							Optional:            true,
+							Computed:            true,
+							Default:             stringdefault.StaticString("md5"),
+							MarkdownDescription: "SNMPv3 authentication protocol: `md5`, `sha1`, `sha224`, `sha256`, `sha384` or `sha512`.",
+							Validators: []validator.String{
+								stringOneOf(snmpAuthProtocols...),
+							},
 						},
-					},
-					Blocks: map[string]schema.Block{
-						"snmp_details": schema.SingleNestedBlock{
-							MarkdownDescription: "SNMP details (v2 supported). Used mainly with type=2.",
-							Attributes: map[string]schema.Attribute{
-								"version": schema.Int64Attribute{
-									Optional:            true,
-									Computed:            true,
-									Default:             int64default.StaticInt64(2),
-									MarkdownDescription: "SNMP version (2 supported).",
-								},
-								"community": schema.StringAttribute{
-									Optional:            true,
-									Computed:            true,
-									Default:             stringdefault.StaticString("{$SNMP_COMMUNITY}"),
-									MarkdownDescription: "SNMP v1/v2c community.",
-								},
+						"auth_passphrase": schema.StringAttribute{
+							Optional:            true,
+							Sensitive:           true,
+							MarkdownDescription: "SNMPv3 authentication passphrase, with `auth_no_priv` and `auth_priv`.",
+						},
+						"priv_protocol": schema.StringAttribute{
+							Optional:            true,
+							Computed:            true,
+							Default:             stringdefault.StaticString("des"),
+							MarkdownDescription: "SNMPv3 privacy protocol: `des`, `aes128`, `aes192`, `aes256`, `aes192c` or `aes256c`.",
+							Validators: []validator.String{
+								stringOneOf(snmpPrivProtocols...),
 							},
 						},
+						"priv_passphrase": schema.StringAttribute{
+							Optional:            true,
+							Sensitive:           true,
+							MarkdownDescription: "SNMPv3 privacy passphrase, with `auth_priv`.",
+						},
+						"context_name": schema.StringAttribute{
+							Optional:            true,
+							MarkdownDescription: "SNMPv3 context name.",
+						},
 					},
 				},
 			},
@@ -188,6 +336,43 @@ func (r *hostResource) Configure(_ context.Context, req resource.ConfigureReques
 	r.client = providerData.Client
 }
 
+func (r *hostResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config hostResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	validateHostMonitoring(config, &resp.Diagnostics)
+	psks := []tlsPSKAttribute{{"tls_psk_wo", config.TLSPSKWO}, {"tls_psk", config.TLSPSK}}
+	validateTLS(ctx, config.TLSConnect, config.TLSAccept, config.TLSPSKIdentity, psks, config.TLSIssuer, config.TLSSubject, &resp.Diagnostics)
+}
+
+// validateHostMonitoring checks that the proxy attributes match monitored_by.
+func validateHostMonitoring(config hostResourceModel, diags *diag.Diagnostics) {
+	if config.MonitoredBy.IsUnknown() {
+		return
+	}
+	monitoredBy := config.MonitoredBy.ValueString()
+	if config.MonitoredBy.IsNull() {
+		monitoredBy = "server"
+	}
+	proxySet := !config.ProxyID.IsNull() || !config.ProxyName.IsNull()
+	switch {
+	case monitoredBy == "proxy" && !proxySet:
+		diags.AddAttributeError(path.Root("proxy_id"), "Missing attribute", "Hosts monitored by a proxy require `proxy_id` or `proxy_name`.")
+	case monitoredBy == "proxy" && !config.ProxyID.IsNull() && !config.ProxyName.IsNull():
+		diags.AddAttributeError(path.Root("proxy_name"), "Invalid attribute combination", "Provide either `proxy_id` or `proxy_name`.")
+	case monitoredBy != "proxy" && proxySet:
+		diags.AddAttributeError(path.Root("proxy_id"), "Invalid attribute combination", "`proxy_id` and `proxy_name` require `monitored_by = \"proxy\"`.")
+	}
+	if monitoredBy == "proxy_group" && config.ProxyGroupID.IsNull() {
+		diags.AddAttributeError(path.Root("proxy_group_id"), "Missing attribute", "Hosts monitored by a proxy group require `proxy_group_id`.")
+	}
+	if monitoredBy != "proxy_group" && !config.ProxyGroupID.IsNull() {
+		diags.AddAttributeError(path.Root("proxy_group_id"), "Invalid attribute combination", "`proxy_group_id` requires `monitored_by = \"proxy_group\"`.")
+	}
+}
+
 func (r *hostResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
 	if r.client == nil || req.Plan.Raw.IsNull() {
 		return
@@ -201,11 +386,14 @@ func (r *hostResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRe
 	resp.Diagnostics.Append(d...)
 	templateIDs, d := resolveTemplateIDs(ctx, r.client, plan)
 	resp.Diagnostics.Append(d...)
+	proxyID, d := resolveProxyID(ctx, r.client, plan)
+	resp.Diagnostics.Append(d...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 	plan.HostGroupIDs, _ = types.SetValueFrom(ctx, types.StringType, groupIDs)
 	plan.TemplateIDs, _ = types.SetValueFrom(ctx, types.StringType, templateIDs)
+	plan.ProxyID = proxyID
 	resp.Diagnostics.Append(resp.Plan.Set(ctx, &plan)...)
 }
 
@@ -224,18 +412,39 @@ func (r *hostResource) Create(ctx context.Context, req resource.CreateRequest, r
 	resp.Diagnostics.Append(d...)
 	interfaces, d := expandInterfaces(plan.Interfaces)
 	resp.Diagnostics.Append(d...)
+	proxyID, d := resolveProxyID(ctx, r.client, plan)
+	resp.Diagnostics.Append(d...)
+	tlsConnect, tlsAccept, d := expandTLS(ctx, plan.TLSConnect, plan.TLSAccept)
+	resp.Diagnostics.Append(d...)
+	// Write-only values are only available in the configuration, never in the plan.
+	var pskWO types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("tls_psk_wo"), &pskWO)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	psk := nullableString(plan.TLSPSK)
+	if !pskWO.IsNull() {
+		psk = pskWO.ValueString()
+	}
 
 	hostID, err := r.client.HostCreate(ctx, zabbix.HostCreateRequest{
-		Host:        plan.Name.ValueString(),
-		Name:        nullableString(plan.VisibleName),
-		Status:      boolToHostStatus(plan.Enabled),
-		Interfaces:  interfaces,
-		GroupIDs:    groupIDs,
-		TemplateIDs: templateIDs,
-		Tags:        tags,
+		Host:         plan.Name.ValueString(),
+		Name:         nullableString(plan.VisibleName),
+		Status:       boolToHostStatus(plan.Enabled),
+		Interfaces:   interfaces,
+		GroupIDs:     groupIDs,
+		TemplateIDs:  templateIDs,
+		Tags:         tags,
+		MonitoredBy:  int(atoi64(nameToCode(hostMonitoredBy, plan.MonitoredBy.ValueString()))),
+		ProxyID:      proxyID.ValueString(),
+		ProxyGroupID: nullableString(plan.ProxyGroupID),
+
+		TLSConnect:     tlsConnect,
+		TLSAccept:      tlsAccept,
+		TLSIssuer:      nullableString(plan.TLSIssuer),
+		TLSSubject:     nullableString(plan.TLSSubject),
+		TLSPSKIdentity: nullableString(plan.TLSPSKIdentity),
+		TLSPSK:         psk,
 	})
 	if err != nil {
 		resp.Diagnostics.AddError("host.create error", err.Error())
@@ -245,6 +454,7 @@ func (r *hostResource) Create(ctx context.Context, req resource.CreateRequest, r
 	plan.ID = types.StringValue(hostID)
 	plan.HostGroupIDs, _ = types.SetValueFrom(ctx, types.StringType, groupIDs)
 	plan.TemplateIDs, _ = types.SetValueFrom(ctx, types.StringType, templateIDs)
+	plan.ProxyID = proxyID
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
 }
 
@@ -297,6 +507,37 @@ func (r *hostResource) Read(ctx context.Context, req resource.ReadRequest, resp
 	} else {
 		state.Tags, _ = tagsToMap(ctx, host.Tags)
 	}
+
+	state.MonitoredBy = types.StringValue(codeToName(hostMonitoredBy, strconv.Itoa(host.MonitoredBy), "server"))
+	state.ProxyID = types.StringNull()
+	state.ProxyGroupID = types.StringNull()
+	switch host.MonitoredBy {
+	case zabbix.HostMonitoredByProxy:
+		state.ProxyID = types.StringValue(host.ProxyID)
+		if !state.ProxyName.IsNull() {
+			proxy, err := r.client.ProxyGetByID(ctx, host.ProxyID)
+			if err != nil {
+				resp.Diagnostics.AddError("proxy.get error", err.Error())
+				return
+			}
+			state.ProxyName = types.StringValue(proxy.Name)
+		}
+	case zabbix.HostMonitoredByProxyGroup:
+		state.ProxyGroupID = types.StringValue(host.ProxyGroupID)
+	}
+	if host.MonitoredBy != zabbix.HostMonitoredByProxy {
+		state.ProxyName = types.StringNull()
+	}
+
+	state.TLSConnect, state.TLSAccept = flattenTLS(ctx, host.TLSConnect, host.TLSAccept)
+	state.TLSIssuer = nullOrString(host.TLSIssuer)
+	state.TLSSubject = nullOrString(host.TLSSubject)
+	// host.get never returns the PSK: keep the configured one while PSK connections are used
+	// (tls_psk_wo is never stored).
+	if (host.TLSConnect|host.TLSAccept)&zabbix.TLSPSK == 0 {
+		state.TLSPSKIdentity = types.StringNull()
+		state.TLSPSK = types.StringNull()
+	}
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 }
 
@@ -317,18 +558,54 @@ func (r *hostResource) Update(ctx context.Context, req resource.UpdateRequest, r
 	resp.Diagnostics.Append(d...)
 	interfaces, d := expandInterfaces(plan.Interfaces)
 	resp.Diagnostics.Append(d...)
+	proxyID, d := resolveProxyID(ctx, r.client, plan)
+	resp.Diagnostics.Append(d...)
+	tlsConnect, tlsAccept, d := expandTLS(ctx, plan.TLSConnect, plan.TLSAccept)
+	resp.Diagnostics.Append(d...)
+	stateConnect, stateAccept, d := expandTLS(ctx, state.TLSConnect, state.TLSAccept)
+	resp.Diagnostics.Append(d...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	// The PSK is only sent with tls_psk, or with tls_psk_wo when its version changes.
+	psk := nullableString(plan.TLSPSK)
+	if psk == "" && !plan.TLSPSKWOVer.Equal(state.TLSPSKWOVer) {
+		var pskWO types.String
+		resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("tls_psk_wo"), &pskWO)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		psk = nullableString(pskWO)
+	}
+	usesPSK := (tlsConnect|tlsAccept)&zabbix.TLSPSK != 0
+	usedPSK := (stateConnect|stateAccept)&zabbix.TLSPSK != 0
+	if usesPSK && psk == "" && (!usedPSK || !plan.TLSPSKIdentity.Equal(state.TLSPSKIdentity)) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("tls_psk_wo_version"),
+			"Missing pre-shared key",
+			"Zabbix requires the key to enable `psk` or change `tls_psk_identity`: change `tls_psk_wo_version` to send `tls_psk_wo`.",
+		)
+		return
+	}
 
 	err := r.client.HostUpdate(ctx, state.ID.ValueString(), zabbix.HostUpdateRequest{
-		Host:        plan.Name.ValueString(),
-		Name:        nullableString(plan.VisibleName),
-		Status:      boolToHostStatus(plan.Enabled),
-		Interfaces:  interfaces,
-		GroupIDs:    groupIDs,
-		TemplateIDs: templateIDs,
-		Tags:        tags,
+		Host:         plan.Name.ValueString(),
+		Name:         nullableString(plan.VisibleName),
+		Status:       boolToHostStatus(plan.Enabled),
+		Interfaces:   interfaces,
+		GroupIDs:     groupIDs,
+		TemplateIDs:  templateIDs,
+		Tags:         tags,
+		MonitoredBy:  int(atoi64(nameToCode(hostMonitoredBy, plan.MonitoredBy.ValueString()))),
+		ProxyID:      proxyID.ValueString(),
+		ProxyGroupID: nullableString(plan.ProxyGroupID),
+
+		TLSConnect:     tlsConnect,
+		TLSAccept:      tlsAccept,
+		TLSIssuer:      nullableString(plan.TLSIssuer),
+		TLSSubject:     nullableString(plan.TLSSubject),
+		TLSPSKIdentity: nullableString(plan.TLSPSKIdentity),
+		TLSPSK:         psk,
 	})
 	if err != nil {
 		resp.Diagnostics.AddError("host.update error", err.Error())
@@ -338,6 +615,7 @@ func (r *hostResource) Update(ctx context.Context, req resource.UpdateRequest, r
 	plan.ID = state.ID
 	plan.HostGroupIDs, _ = types.SetValueFrom(ctx, types.StringType, groupIDs)
 	plan.TemplateIDs, _ = types.SetValueFrom(ctx, types.StringType, templateIDs)
+	plan.ProxyID = proxyID
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
 }
 
@@ -488,6 +766,26 @@ func resolveTemplateIDs(ctx context.Context, client *zabbix.Client, plan hostRes
 	return resolvedIDs, diags
 }
 
+// resolveProxyID returns the ID of the proxy monitoring the host, looked up by name with proxy_name;
+// null when the host is not monitored by a proxy.
+func resolveProxyID(ctx context.Context, client *zabbix.Client, plan hostResourceModel) (types.String, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	switch {
+	case plan.MonitoredBy.ValueString() != "proxy":
+		return types.StringNull(), diags
+	case plan.ProxyName.IsUnknown():
+		return types.StringUnknown(), diags
+	case plan.ProxyName.IsNull():
+		return plan.ProxyID, diags
+	}
+	id, err := client.ProxyIDByName(ctx, plan.ProxyName.ValueString())
+	if err != nil {
+		diags.AddAttributeError(path.Root("proxy_name"), "Cannot resolve proxy", err.Error())
+		return types.StringNull(), diags
+	}
+	return types.StringValue(id), diags
+}
+
 func mapToTags(ctx context.Context, value types.Map) ([]zabbix.Tag, diag.Diagnostics) {
 	if value.IsNull() || value.IsUnknown() {
 		return nil, nil
@@ -546,24 +844,20 @@ func expandInterfaces(interfaces []hostInterfaceModel) ([]zabbix.HostInterface,
 
 		iface := zabbix.HostInterface{
 			Type:  int(it.Type.ValueInt64()),
-			Main:  boolToInt(!it.Main.IsNull() && it.Main.ValueBool()),
-			UseIP: boolToInt(useIP),
+			Main:  zabbix.BoolToInt(!it.Main.IsNull() && it.Main.ValueBool()),
+			UseIP: zabbix.BoolToInt(useIP),
 			IP:    nullableString(it.IP),
 			DNS:   nullableString(it.DNS),
 			Port:  port,
 		}
 
 		if iface.Type == 2 {
-			details := expandSNMPDetails(it.SNMPDetails)
-			if details.Version != 2 {
-				diags.AddAttributeError(
-					path.Root("interfaces").AtListIndex(index).AtName("snmp_details").AtName("version"),
-"Unsupported SNMP version",
-				"This provider currently only supports SNMP v2 for hosts.",
-				)
+			d := validateSNMPDetails(path.Root("interfaces").AtListIndex(index).AtName("snmp_details"), it.SNMPDetails)
+			diags.Append(d...)
+			if d.HasError() {
 				continue
 			}
-			iface.Details = details
+			iface.Details = expandSNMPDetails(it.SNMPDetails)
 		}
 
 		out = append(out, iface)
@@ -584,20 +878,59 @@ func flattenInterfaces(interfaces []zabbix.HostInterface) []hostInterfaceModel {
 			Port:  types.StringValue(it.Port),
 		})
 		if it.Type == 2 && it.Details != nil && int(it.Details.Version) != 0 {
-			out[len(out)-1].SNMPDetails = &hostSNMPDetailsModel{
-				Version:   types.Int64Value(int64(it.Details.Version)),
-				Community: nullOrString(it.Details.Community),
-			}
+			out[len(out)-1].SNMPDetails = flattenSNMPDetails(it.Details)
 		}
 	}
 	return out
 }
 
+// validateSNMPDetails checks the version and the SNMPv3 settings it requires.
+func validateSNMPDetails(detailsPath path.Path, in *hostSNMPDetailsModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if in == nil {
+		return diags
+	}
+	version := in.Version.ValueInt64()
+	if version < 1 || version > 3 {
+		diags.AddAttributeError(detailsPath.AtName("version"), "Unsupported SNMP version", "SNMP version is 1, 2 or 3.")
+		return diags
+	}
+	if v := in.MaxRepetitions; !v.IsNull() && !v.IsUnknown() && (v.ValueInt64() < 1 || v.ValueInt64() > 100) {
+		diags.AddAttributeError(detailsPath.AtName("max_repetitions"), "Invalid attribute value", "`max_repetitions` is between 1 and 100.")
+	}
+	if version != 3 {
+		for _, attr := range []struct {
+			name  string
+			value types.String
+		}{
+			{"security_name", in.SecurityName},
+			{"auth_passphrase", in.AuthPassphrase},
+			{"priv_passphrase", in.PrivPassphrase},
+			{"context_name", in.ContextName},
+		} {
+			if !attr.value.IsNull() {
+				diags.AddAttributeError(detailsPath.AtName(attr.name), "Invalid attribute combination", fmt.Sprintf("`%s` is only used by SNMPv3.", attr.name))
+			}
+		}
+		return diags
+	}
+	level := in.SecurityLevel.ValueString()
+	if level != "no_auth_no_priv" && in.AuthPassphrase.IsNull() {
+		diags.AddAttributeError(detailsPath.AtName("auth_passphrase"), "Missing attribute", fmt.Sprintf("Security level `%s` requires `auth_passphrase`.", level))
+	}
+	if level == "auth_priv" && in.PrivPassphrase.IsNull() {
+		diags.AddAttributeError(detailsPath.AtName("priv_passphrase"), "Missing attribute", "Security level `auth_priv` requires `priv_passphrase`.")
+	}
+	return diags
+}
+
 func expandSNMPDetails(in *hostSNMPDetailsModel) *zabbix.SNMPDetails {
 	if in == nil {
 		return &zabbix.SNMPDetails{
-			Version:   2,
-			Community: "{$SNMP_COMMUNITY}",
+			Version:        2,
+			Bulk:           1,
+			MaxRepetitions: 10,
+			Community:      snmpDefaultCommunity,
 		}
 	}
 
@@ -608,18 +941,57 @@ func expandSNMPDetails(in *hostSNMPDetailsModel) *zabbix.SNMPDetails {
 
 	community := nullableString(in.Community)
 	if community == "" {
-		community = "{$SNMP_COMMUNITY}"
+		community = snmpDefaultCommunity
 	}
 
 	return &zabbix.SNMPDetails{
-		Version:   zabbix.FlexIntFrom(version),
-		Community: community,
+		Version:        zabbix.FlexIntFrom(version),
+		Bulk:           zabbix.FlexIntFrom(zabbix.BoolToInt(in.Bulk.IsNull() || in.Bulk.ValueBool())),
+		MaxRepetitions: zabbix.FlexInt(in.MaxRepetitions.ValueInt64()),
+		Community:      community,
+		Security:       nullableString(in.SecurityName),
+		SecurityLevel:  zabbix.FlexInt(atoi64(nameToCode(snmpSecurityLevels, in.SecurityLevel.ValueString()))),
+		AuthProto:      zabbix.FlexInt(atoi64(nameToCode(snmpAuthProtocols, in.AuthProtocol.ValueString()))),
+		AuthPass:       nullableString(in.AuthPassphrase),
+		PrivProto:      zabbix.FlexInt(atoi64(nameToCode(snmpPrivProtocols, in.PrivProtocol.ValueString()))),
+		PrivPass:       nullableString(in.PrivPassphrase),
+		Context:        nullableString(in.ContextName),
 	}
 }
 
-func boolToInt(value bool) int {
-	if value {
-		return 1
+// flattenSNMPDetails reports the attributes of other versions with their schema defaults so the plan stays empty.
+func flattenSNMPDetails(in *zabbix.SNMPDetails) *hostSNMPDetailsModel {
+	out := &hostSNMPDetailsModel{
+		Version:        types.Int64Value(int64(in.Version)),
+		Bulk:           types.BoolValue(in.Bulk == 1),
+		MaxRepetitions: types.Int64Value(int64(in.MaxRepetitions)),
+		Community:      types.StringValue(snmpDefaultCommunity),
+		SecurityName:   types.StringNull(),
+		SecurityLevel:  types.StringValue("no_auth_no_priv"),
+		AuthProtocol:   types.StringValue("md5"),
+		AuthPassphrase: types.StringNull(),
+		PrivProtocol:   types.StringValue("des"),
+		PrivPassphrase: types.StringNull(),
+		ContextName:    types.StringNull(),
+	}
+	if in.MaxRepetitions == 0 {
+		// Before Zabbix 7.0.
+		out.MaxRepetitions = types.Int64Value(10)
+	}
+	if in.Version != 3 {
+		out.Community = nullOrString(in.Community)
+		return out
+	}
+	out.SecurityName = nullOrString(in.Security)
+	out.SecurityLevel = types.StringValue(codeToName(snmpSecurityLevels, strconv.Itoa(int(in.SecurityLevel)), "no_auth_no_priv"))
+	out.ContextName = nullOrString(in.Context)
+	if in.SecurityLevel != zabbix.SNMPSecurityNoAuthNoPriv {
+		out.AuthProtocol = types.StringValue(codeToName(snmpAuthProtocols, strconv.Itoa(int(in.AuthProto)), "md5"))
+		out.AuthPassphrase = nullOrString(in.AuthPass)
+	}
+	if in.SecurityLevel == zabbix.SNMPSecurityAuthPriv {
+		out.PrivProtocol = types.StringValue(codeToName(snmpPrivProtocols, strconv.Itoa(int(in.PrivProto)), "des"))
+		out.PrivPassphrase = nullOrString(in.PrivPass)
 	}
-	return 0
+	return out
 }
@@ -8,6 +8,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
@@ -24,8 +25,10 @@ type hostGroupResource struct {
 }
 
 type hostGroupResourceModel struct {
-	ID   types.String `tfsdk:"id"`
-	Name types.String `tfsdk:"name"`
+	ID                   types.String `tfsdk:"id"`
+	Name                 types.String `tfsdk:"name"`
+	PropagatePermissions types.Bool   `tfsdk:"propagate_permissions"`
+	PropagateTagFilters  types.Bool   `tfsdk:"propagate_tag_filters"`
 }
 
 func NewHostGroupResource() resource.Resource {
@@ -49,6 +52,18 @@ func (r *hostGroupResource) Schema(_ context.Context, _ resource.SchemaRequest,
 			"name": schema.StringAttribute{
 				Required: true,
 			},
+			"propagate_permissions": schema.BoolAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+				MarkdownDescription: "Propagate this group's user group permissions to its nested subgroups (e.g. \"Linux/Prod\" under \"Linux\") after create and update. Zabbix 6.2+.",
+			},
+			"propagate_tag_filters": schema.BoolAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+				MarkdownDescription: "Propagate this group's user group tag filters to its nested subgroups after create and update. Zabbix 6.2+.",
+			},
 		},
 	}
 }
@@ -77,9 +92,16 @@ func (r *hostGroupResource) Create(ctx context.Context, req resource.CreateReque
 		resp.Diagnostics.AddError("hostgroup.create error", err.Error())
 		return
 	}
-
+	// Save the ID first so a failed propagation does not orphan the group.
 	plan.ID = types.StringValue(id)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+
+	if err := r.client.HostGroupPropagate(ctx, id, plan.PropagatePermissions.ValueBool(), plan.PropagateTagFilters.ValueBool()); err != nil {
+		resp.Diagnostics.AddError("hostgroup.propagate error", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
 }
 
 func (r *hostGroupResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -116,6 +138,10 @@ func (r *hostGroupResource) Update(ctx context.Context, req resource.UpdateReque
 		resp.Diagnostics.AddError("hostgroup.update error", err.Error())
 		return
 	}
+	if err := r.client.HostGroupPropagate(ctx, state.ID.ValueString(), plan.PropagatePermissions.ValueBool(), plan.PropagateTagFilters.ValueBool()); err != nil {
+		resp.Diagnostics.AddError("hostgroup.propagate error", err.Error())
+		return
+	}
 
 	plan.ID = state.ID
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
@@ -0,0 +1,310 @@
+package provider
+
+import (
+	"context"
+	"sort"
+	"strconv"
+
+	"github.com/rushiii/terraform-provider-zabbix/internal/zabbix"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ resource.Resource                   = &hostPrototypeResource{}
+	_ resource.ResourceWithConfigure      = &hostPrototypeResource{}
+	_ resource.ResourceWithImportState    = &hostPrototypeResource{}
+	_ resource.ResourceWithValidateConfig = &hostPrototypeResource{}
+)
+
+type hostPrototypeResource struct {
+	client *zabbix.Client
+}
+
+type hostPrototypeResourceModel struct {
+	ID              types.String         `tfsdk:"id"`
+	RuleID          types.String         `tfsdk:"rule_id"`
+	Name            types.String         `tfsdk:"name"`
+	VisibleName     types.String         `tfsdk:"visible_name"`
+	Enabled         types.Bool           `tfsdk:"enabled"`
+	Discover        types.Bool           `tfsdk:"discover"`
+	HostGroupIDs    types.Set            `tfsdk:"host_group_ids"`
+	GroupPrototypes types.Set            `tfsdk:"group_prototypes"`
+	TemplateIDs     types.Set            `tfsdk:"template_ids"`
+	Macros          types.Map            `tfsdk:"macros"`
+	Tags            types.Map            `tfsdk:"tags"`
+	InventoryMode   types.String         `tfsdk:"inventory_mode"`
+	Interfaces      []hostInterfaceModel `tfsdk:"interfaces"`
+}
+
+func NewHostPrototypeResource() resource.Resource {
+	return &hostPrototypeResource{}
+}
+
+func (r *hostPrototypeResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_host_prototype"
+}
+
+func (r *hostPrototypeResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Zabbix host prototype resource: hosts created by a discovery rule for each discovered entity (VMs, Kubernetes nodes, ...).",
+		Attributes: prototypeAttributes(map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				MarkdownDescription: "Internal Zabbix ID.",
+			},
+			"name": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Technical name (`host`) of the discovered hosts, with at least one LLD macro (e.g. `{#VM.UUID}`).",
+			},
+			"visible_name": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Visible name (`name`) of the discovered hosts, e.g. `{#VM.NAME}`.",
+			},
+			"enabled": schema.BoolAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(true),
+				MarkdownDescription: "Whether the discovered hosts are enabled.",
+			},
+			"host_group_ids": schema.SetAttribute{
+				Required:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "IDs of existing host groups of the discovered hosts.",
+			},
+			"group_prototypes": schema.SetAttribute{
+				Optional:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "Names of host groups created for the discovered hosts, with LLD macros (e.g. `Cluster {#CLUSTER.NAME}`).",
+			},
+			"template_ids": schema.SetAttribute{
+				Optional:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "IDs of the templates linked to the discovered hosts.",
+			},
+			"macros": schema.MapAttribute{
+				Optional:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "User macros of the discovered hosts (e.g. `{\"{$VM.UUID}\" = \"{#VM.UUID}\"}`).",
+			},
+			"tags": schema.MapAttribute{
+				Optional:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "Tags map (tag => value).",
+			},
+			"inventory_mode": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString("disabled"),
+				MarkdownDescription: "Inventory mode of the discovered hosts: `disabled`, `manual` or `automatic`. Default: `disabled`.",
+				Validators: []validator.String{
+					stringOneOf(lldInventoryModes...),
+				},
+			},
+		}),
+		Blocks: map[string]schema.Block{
+			"interfaces": hostInterfacesBlock("Custom interfaces of the discovered hosts. Without them, the discovered hosts inherit the interfaces of the host of the discovery rule."),
+		},
+	}
+}
+
+func (r *hostPrototypeResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	providerData, ok := req.ProviderData.(*providerData)
+	if !ok || providerData.Client == nil {
+		resp.Diagnostics.AddError("Invalid provider", "Zabbix client unavailable.")
+		return
+	}
+	r.client = providerData.Client
+}
+
+func (r *hostPrototypeResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config hostPrototypeResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if !config.Name.IsUnknown() && !lldMacroRef.MatchString(config.Name.ValueString()) {
+		resp.Diagnostics.AddAttributeError(path.Root("name"), "Invalid attribute value", "Host prototype names contain at least one LLD macro, e.g. `{#VM.UUID}`.")
+	}
+}
+
+func (r *hostPrototypeResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan hostPrototypeResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	zreq, d := expandHostPrototype(ctx, plan)
+	resp.Diagnostics.Append(d...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	id, err := r.client.HostPrototypeCreate(ctx, zreq)
+	if err != nil {
+		resp.Diagnostics.AddError("hostprototype.create error", err.Error())
+		return
+	}
+
+	plan.ID = types.StringValue(id)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *hostPrototypeResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state hostPrototypeResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	prototype, err := r.client.HostPrototypeGetByID(ctx, state.ID.ValueString())
+	if err != nil {
+		if zabbix.IsNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError("hostprototype.get error", err.Error())
+		return
+	}
+
+	state.RuleID = types.StringValue(prototype.DiscoveryRule.ItemID)
+	state.Name = types.StringValue(prototype.Host)
+	// Zabbix copies host into name when no visible name is set.
+	if prototype.Name != prototype.Host || !state.VisibleName.IsNull() {
+		state.VisibleName = nullOrString(prototype.Name)
+	}
+	state.Enabled = types.BoolValue(zabbix.StatusToEnabled(prototype.Status))
+	state.Discover = types.BoolValue(zabbix.StatusToEnabled(prototype.Discover))
+	state.InventoryMode = types.StringValue(codeToName(lldInventoryModes, strconv.Itoa(int(prototype.InventoryMode)+1), "disabled"))
+
+	groupIDs := make([]string, 0, len(prototype.GroupLinks))
+	for _, g := range prototype.GroupLinks {
+		groupIDs = append(groupIDs, g.GroupID)
+	}
+	state.HostGroupIDs, _ = types.SetValueFrom(ctx, types.StringType, groupIDs)
+	groupPrototypes := make([]string, 0, len(prototype.GroupPrototypes))
+	for _, g := range prototype.GroupPrototypes {
+		groupPrototypes = append(groupPrototypes, g.Name)
+	}
+	if len(groupPrototypes) > 0 || !state.GroupPrototypes.IsNull() {
+		state.GroupPrototypes, _ = types.SetValueFrom(ctx, types.StringType, groupPrototypes)
+	}
+	templateIDs := make([]string, 0, len(prototype.Templates))
+	for _, t := range prototype.Templates {
+		templateIDs = append(templateIDs, t.TemplateID)
+	}
+	if len(templateIDs) > 0 || !state.TemplateIDs.IsNull() {
+		state.TemplateIDs, _ = types.SetValueFrom(ctx, types.StringType, templateIDs)
+	}
+
+	macros := make(map[string]string, len(prototype.Macros))
+	for _, m := range prototype.Macros {
+		macros[m.Macro] = m.Value
+	}
+	if len(macros) > 0 || !state.Macros.IsNull() {
+		state.Macros, _ = types.MapValueFrom(ctx, types.StringType, macros)
+	}
+	if len(prototype.Tags) > 0 || !state.Tags.IsNull() {
+		tags, d := tagsToMap(ctx, prototype.Tags)
+		resp.Diagnostics.Append(d...)
+		state.Tags = tags
+	}
+
+	if prototype.CustomInterfaces == "1" {
+		state.Interfaces = flattenInterfaces(prototype.Interfaces)
+	} else {
+		state.Interfaces = make([]hostInterfaceModel, 0)
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *hostPrototypeResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan hostPrototypeResourceModel
+	var state hostPrototypeResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	zreq, d := expandHostPrototype(ctx, plan)
+	resp.Diagnostics.Append(d...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if err := r.client.HostPrototypeUpdate(ctx, state.ID.ValueString(), zreq); err != nil {
+		resp.Diagnostics.AddError("hostprototype.update error", err.Error())
+		return
+	}
+
+	plan.ID = state.ID
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *hostPrototypeResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state hostPrototypeResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	err := r.client.HostPrototypeDelete(ctx, state.ID.ValueString())
+	if err != nil && !zabbix.IsNotFound(err) {
+		resp.Diagnostics.AddError("hostprototype.delete error", err.Error())
+	}
+}
+
+func (r *hostPrototypeResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+// expandHostPrototype builds the hostprototype.create/update request from the plan.
+func expandHostPrototype(ctx context.Context, plan hostPrototypeResourceModel) (zabbix.HostPrototypeCreateRequest, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	groupIDs, d := setToStrings(ctx, plan.HostGroupIDs)
+	diags.Append(d...)
+	groupPrototypes, d := setToStringsOptional(ctx, plan.GroupPrototypes)
+	diags.Append(d...)
+	templateIDs, d := setToStringsOptional(ctx, plan.TemplateIDs)
+	diags.Append(d...)
+	tags, d := mapToTags(ctx, plan.Tags)
+	diags.Append(d...)
+	interfaces, d := expandInterfaces(plan.Interfaces)
+	diags.Append(d...)
+
+	var macros []zabbix.HostPrototypeMacro
+	for macro, value := range templateMacrosFromPlan(plan.Macros) {
+		macros = append(macros, zabbix.HostPrototypeMacro{Macro: macro, Value: value})
+	}
+	sort.Slice(macros, func(i, j int) bool { return macros[i].Macro < macros[j].Macro })
+
+	return zabbix.HostPrototypeCreateRequest{
+		RuleID:          plan.RuleID.ValueString(),
+		Host:            plan.Name.ValueString(),
+		Name:            nullableString(plan.VisibleName),
+		Status:          boolToHostStatus(plan.Enabled),
+		Discover:        plan.Discover.ValueBool(),
+		InventoryMode:   int(atoi64(nameToCode(lldInventoryModes, plan.InventoryMode.ValueString()))) - 1,
+		Interfaces:      interfaces,
+		GroupIDs:        groupIDs,
+		GroupPrototypes: groupPrototypes,
+		TemplateIDs:     templateIDs,
+		Macros:          macros,
+		Tags:            tags,
+	}, diags
+}
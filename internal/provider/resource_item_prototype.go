@@ -0,0 +1,216 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/rushiii/terraform-provider-zabbix/internal/zabbix"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ resource.Resource                   = &itemPrototypeResource{}
+	_ resource.ResourceWithConfigure      = &itemPrototypeResource{}
+	_ resource.ResourceWithImportState    = &itemPrototypeResource{}
+	_ resource.ResourceWithValidateConfig = &itemPrototypeResource{}
+)
+
+type itemPrototypeResource struct {
+	client *zabbix.Client
+}
+
+type itemPrototypeResourceModel struct {
+	itemSourceModel
+	itemValueModel
+	RuleID   types.String `tfsdk:"rule_id"`
+	Discover types.Bool   `tfsdk:"discover"`
+}
+
+func NewItemPrototypeResource() resource.Resource {
+	return &itemPrototypeResource{}
+}
+
+func (r *itemPrototypeResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_item_prototype"
+}
+
+// prototypeAttributes adds the rule_id and discover attributes of prototypes to attributes.
+func prototypeAttributes(attributes map[string]schema.Attribute) map[string]schema.Attribute {
+	attributes["rule_id"] = schema.StringAttribute{
+		Required: true,
+		PlanModifiers: []planmodifier.String{
+			stringplanmodifier.RequiresReplace(),
+		},
+		MarkdownDescription: "ID of the discovery rule (`zabbix_discovery_rule`). Changing this forces recreation.",
+	}
+	attributes["discover"] = schema.BoolAttribute{
+		Optional:            true,
+		Computed:            true,
+		Default:             booldefault.StaticBool(true),
+		MarkdownDescription: "Whether entities are discovered from the prototype. Default: true.",
+	}
+	return attributes
+}
+
+func (r *itemPrototypeResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	attributes := prototypeAttributes(itemAttributes())
+	// Item prototypes cannot populate the host inventory.
+	delete(attributes, "inventory_link")
+	attributes["host_id"] = schema.StringAttribute{
+		Computed: true,
+		PlanModifiers: []planmodifier.String{
+			stringplanmodifier.UseStateForUnknown(),
+		},
+		MarkdownDescription: "ID of the host or template of the discovery rule.",
+	}
+	attributes["name"] = schema.StringAttribute{
+		Required:            true,
+		MarkdownDescription: "Item prototype name, usually with LLD macros (e.g. `Interface {#IFNAME}: Bits received`).",
+	}
+	attributes["key"] = schema.StringAttribute{
+		Required:            true,
+		MarkdownDescription: "Item prototype key with at least one LLD macro (e.g. `net.if.in[{#IFNAME}]`).",
+	}
+
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Zabbix item prototype resource: items created by a discovery rule for each discovered entity. Supports the item types and attributes of `zabbix_item`, except `inventory_link`.",
+		Attributes:          attributes,
+		Blocks:              itemBlocks(),
+	}
+}
+
+func (r *itemPrototypeResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	providerData, ok := req.ProviderData.(*providerData)
+	if !ok || providerData.Client == nil {
+		resp.Diagnostics.AddError("Invalid provider", "Zabbix client unavailable.")
+		return
+	}
+	r.client = providerData.Client
+}
+
+func (r *itemPrototypeResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config itemPrototypeResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	validateItemSource(config.itemSourceModel, itemTypes, &resp.Diagnostics)
+	validateItemValue(config.itemValueModel, &resp.Diagnostics)
+	if !config.Key.IsUnknown() && !lldMacroRef.MatchString(config.Key.ValueString()) {
+		resp.Diagnostics.AddAttributeError(path.Root("key"), "Invalid attribute value", "Item prototype keys contain at least one LLD macro, e.g. `net.if.in[{#IFNAME}]`.")
+	}
+}
+
+func (r *itemPrototypeResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan itemPrototypeResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	zreq, d := expandItemPrototype(ctx, plan)
+	resp.Diagnostics.Append(d...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	id, err := r.client.ItemPrototypeCreate(ctx, zreq)
+	if err != nil {
+		resp.Diagnostics.AddError("itemprototype.create error", err.Error())
+		return
+	}
+
+	// Read back the host of the rule and the resolved interface.
+	prototype, err := r.client.ItemPrototypeGetByID(ctx, id)
+	if err != nil {
+		resp.Diagnostics.AddError("itemprototype.get error", err.Error())
+		return
+	}
+	plan.ID = types.StringValue(id)
+	plan.HostID = types.StringValue(prototype.HostID)
+	plan.InterfaceID = types.StringValue(prototype.InterfaceID)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *itemPrototypeResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state itemPrototypeResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	prototype, err := r.client.ItemPrototypeGetByID(ctx, state.ID.ValueString())
+	if err != nil {
+		if zabbix.IsNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError("itemprototype.get error", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(flattenItemSource(ctx, &prototype.Item, &state.itemSourceModel)...)
+	resp.Diagnostics.Append(flattenItemValue(ctx, &prototype.Item, &state.itemValueModel)...)
+	state.RuleID = types.StringValue(prototype.DiscoveryRule.ItemID)
+	state.Discover = types.BoolValue(zabbix.StatusToEnabled(prototype.Discover))
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *itemPrototypeResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan itemPrototypeResourceModel
+	var state itemPrototypeResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	zreq, d := expandItemPrototype(ctx, plan)
+	resp.Diagnostics.Append(d...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if err := r.client.ItemPrototypeUpdate(ctx, state.ID.ValueString(), zreq); err != nil {
+		resp.Diagnostics.AddError("itemprototype.update error", err.Error())
+		return
+	}
+
+	plan.ID = state.ID
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *itemPrototypeResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state itemPrototypeResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	err := r.client.ItemPrototypeDelete(ctx, state.ID.ValueString())
+	if err != nil && !zabbix.IsNotFound(err) {
+		resp.Diagnostics.AddError("itemprototype.delete error", err.Error())
+	}
+}
+
+func (r *itemPrototypeResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+// expandItemPrototype builds the itemprototype.create/update request from the plan.
+func expandItemPrototype(ctx context.Context, plan itemPrototypeResourceModel) (zabbix.ItemPrototypeCreateRequest, diag.Diagnostics) {
+	req, diags := expandItemSource(ctx, plan.itemSourceModel)
+	diags.Append(expandItemValue(ctx, plan.itemValueModel, &req)...)
+	return zabbix.ItemPrototypeCreateRequest{
+		ItemCreateRequest: req,
+		RuleID:            plan.RuleID.ValueString(),
+		Discover:          plan.Discover.ValueBool(),
+	}, diags
+}
@@ -0,0 +1,649 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/rushiii/terraform-provider-zabbix/internal/zabbix"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ resource.Resource                   = &maintenanceResource{}
+	_ resource.ResourceWithConfigure      = &maintenanceResource{}
+	_ resource.ResourceWithImportState    = &maintenanceResource{}
+	_ resource.ResourceWithValidateConfig = &maintenanceResource{}
+)
+
+// Enums of maintenances, indexed by Zabbix code.
+var (
+	maintenancePeriodTypes = []string{
+		zabbix.MaintenancePeriodOneTime: "one_time",
+		zabbix.MaintenancePeriodDaily:   "daily",
+		zabbix.MaintenancePeriodWeekly:  "weekly",
+		zabbix.MaintenancePeriodMonthly: "monthly",
+	}
+	maintenanceTagsEvalTypes = []string{0: "and_or", 2: "or"}
+	maintenanceTagOperators  = []string{0: "equals", 2: "contains"}
+	// maintenanceWeekdays and maintenanceMonths are indexed by bit of the Zabbix bitmasks.
+	maintenanceWeekdays = []string{"monday", "tuesday", "wednesday", "thursday", "friday", "saturday", "sunday"}
+	maintenanceMonths   = []string{
+		"january", "february", "march", "april", "may", "june", "july", "august", "september", "october", "november", "december",
+	}
+)
+
+var (
+	// maintenanceDuration matches periods in seconds or with a time suffix, e.g. 3600, 90m, 2h, 1d, 1w.
+	maintenanceDuration = regexp.MustCompile(`^(\d+)([smhdw]?)$`)
+	// maintenanceTimeOfDay matches start times, e.g. 22:30.
+	maintenanceTimeOfDay = regexp.MustCompile(`^([01]?\d|2[0-3]):([0-5]\d)$`)
+)
+
+// maintenancePeriodAttributes lists the timeperiod attributes with the period types that use them.
+var maintenancePeriodAttributes = []struct {
+	name  string
+	types []string
+}{
+	{"start_date", []string{"one_time"}},
+	{"start_time", []string{"daily", "weekly", "monthly"}},
+	{"every", []string{"daily", "weekly", "monthly"}},
+	{"days_of_week", []string{"weekly", "monthly"}},
+	{"months", []string{"monthly"}},
+	{"day", []string{"monthly"}},
+}
+
+type maintenanceResource struct {
+	client *zabbix.Client
+}
+
+type maintenanceResourceModel struct {
+	ID             types.String                 `tfsdk:"id"`
+	Name           types.String                 `tfsdk:"name"`
+	Description    types.String                 `tfsdk:"description"`
+	DataCollection types.Bool                   `tfsdk:"data_collection"`
+	ActiveSince    types.String                 `tfsdk:"active_since"`
+	ActiveTill     types.String                 `tfsdk:"active_till"`
+	HostIDs        types.Set                    `tfsdk:"host_ids"`
+	HostGroupIDs   types.Set                    `tfsdk:"host_group_ids"`
+	TagsEvalType   types.String                 `tfsdk:"tags_evaltype"`
+	Tags           []maintenanceTagModel        `tfsdk:"tag"`
+	TimePeriods    []maintenanceTimePeriodModel `tfsdk:"timeperiod"`
+}
+
+type maintenanceTagModel struct {
+	Tag      types.String `tfsdk:"tag"`
+	Operator types.String `tfsdk:"operator"`
+	Value    types.String `tfsdk:"value"`
+}
+
+type maintenanceTimePeriodModel struct {
+	Type       types.String `tfsdk:"type"`
+	Period     types.String `tfsdk:"period"`
+	StartDate  types.String `tfsdk:"start_date"`
+	StartTime  types.String `tfsdk:"start_time"`
+	Every      types.Int64  `tfsdk:"every"`
+	DaysOfWeek types.Set    `tfsdk:"days_of_week"`
+	Months     types.Set    `tfsdk:"months"`
+	Day        types.Int64  `tfsdk:"day"`
+}
+
+func NewMaintenanceResource() resource.Resource {
+	return &maintenanceResource{}
+}
+
+func (r *maintenanceResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_maintenance"
+}
+
+func (r *maintenanceResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Zabbix maintenance resource: periods during which hosts raise no problems or collect no data.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				MarkdownDescription: "Internal Zabbix ID.",
+			},
+			"name": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Maintenance name.",
+			},
+			"description": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Description, e.g. the change ticket.",
+			},
+			"data_collection": schema.BoolAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(true),
+				MarkdownDescription: "Keep collecting data during the maintenance; false stops data collection. Default: true.",
+			},
+			"active_since": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Start of the validity of the maintenance, in RFC3339 format (e.g. `2026-10-20T22:00:00+02:00`).",
+			},
+			"active_till": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "End of the validity of the maintenance, in RFC3339 format.",
+			},
+			"host_ids": schema.SetAttribute{
+				Optional:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "IDs of the hosts in maintenance.",
+			},
+			"host_group_ids": schema.SetAttribute{
+				Optional:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "IDs of the host groups in maintenance.",
+			},
+			"tags_evaltype": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString("and_or"),
+				MarkdownDescription: "How `tag` filters are combined: `and_or` (and between tag names, or within a name) or `or`. Default: `and_or`.",
+				Validators: []validator.String{
+					stringOneOf("and_or", "or"),
+				},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"tag": schema.ListNestedBlock{
+				MarkdownDescription: "Only suppress problems with this tag (with data collection only). Without tags, all problems of the hosts are suppressed.",
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"tag": schema.StringAttribute{
+							Required: true,
+						},
+						"operator": schema.StringAttribute{
+							Optional:            true,
+							Computed:            true,
+							Default:             stringdefault.StaticString("contains"),
+							MarkdownDescription: "`equals` or `contains`. Default: `contains`.",
+							Validators: []validator.String{
+								stringOneOf("equals", "contains"),
+							},
+						},
+						"value": schema.StringAttribute{
+							Optional: true,
+							Computed: true,
+							Default:  stringdefault.StaticString(""),
+						},
+					},
+				},
+			},
+			"timeperiod": schema.ListNestedBlock{
+				MarkdownDescription: "Maintenance period within `active_since` and `active_till`.",
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"type": schema.StringAttribute{
+							Optional:            true,
+							Computed:            true,
+							Default:             stringdefault.StaticString("one_time"),
+							MarkdownDescription: "`one_time`, `daily`, `weekly` or `monthly`. Default: `one_time`.",
+							Validators: []validator.String{
+								stringOneOf("one_time", "daily", "weekly", "monthly"),
+							},
+						},
+						"period": schema.StringAttribute{
+							Optional:            true,
+							Computed:            true,
+							Default:             stringdefault.StaticString("1h"),
+							MarkdownDescription: "Duration, in seconds or with a suffix (`90m`, `2h`, `1d`, `1w`); at least 5 minutes. Default: `1h`.",
+						},
+						"start_date": schema.StringAttribute{
+							Optional:            true,
+							MarkdownDescription: "Start of a `one_time` period, in RFC3339 format.",
+						},
+						"start_time": schema.StringAttribute{
+							Optional:            true,
+							MarkdownDescription: "Start time of `daily`, `weekly` and `monthly` periods, `HH:MM` in the server time zone.",
+						},
+						"every": schema.Int64Attribute{
+							Optional:            true,
+							Computed:            true,
+							Default:             int64default.StaticInt64(1),
+							MarkdownDescription: "Every N days (`daily`) or weeks (`weekly`); for `monthly` with `days_of_week`, the week of the month (1-4, 5 for the last). Default: 1.",
+						},
+						"days_of_week": schema.SetAttribute{
+							Optional:            true,
+							ElementType:         types.StringType,
+							MarkdownDescription: "Days (`monday` ... `sunday`) of `weekly` periods and of `monthly` periods by weekday.",
+						},
+						"months": schema.SetAttribute{
+							Optional:            true,
+							ElementType:         types.StringType,
+							MarkdownDescription: "Months (`january` ... `december`) of `monthly` periods.",
+						},
+						"day": schema.Int64Attribute{
+							Optional:            true,
+							MarkdownDescription: "Day of the month (1-31) of `monthly` periods without `days_of_week`.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (r *maintenanceResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	providerData, ok := req.ProviderData.(*providerData)
+	if !ok || providerData.Client == nil {
+		resp.Diagnostics.AddError("Invalid provider", "Zabbix client unavailable.")
+		return
+	}
+	r.client = providerData.Client
+}
+
+func (r *maintenanceResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config maintenanceResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	since, sinceOK := validateMaintenanceTimestamp(path.Root("active_since"), config.ActiveSince, &resp.Diagnostics)
+	till, tillOK := validateMaintenanceTimestamp(path.Root("active_till"), config.ActiveTill, &resp.Diagnostics)
+	if sinceOK && tillOK && !till.After(since) {
+		resp.Diagnostics.AddAttributeError(path.Root("active_till"), "Invalid attribute value", "`active_till` is after `active_since`.")
+	}
+	if config.HostIDs.IsNull() && config.HostGroupIDs.IsNull() {
+		resp.Diagnostics.AddAttributeError(path.Root("host_ids"), "Missing attribute", "Provide `host_ids`, `host_group_ids` or both.")
+	}
+	if len(config.Tags) > 0 && !config.DataCollection.IsNull() && !config.DataCollection.IsUnknown() && !config.DataCollection.ValueBool() {
+		resp.Diagnostics.AddAttributeError(path.Root("tag"), "Invalid attribute combination", "`tag` filters require `data_collection = true`.")
+	}
+	if len(config.TimePeriods) == 0 {
+		resp.Diagnostics.AddAttributeError(path.Root("timeperiod"), "Missing attribute", "Maintenances have at least one `timeperiod` block.")
+	}
+
+	for i, period := range config.TimePeriods {
+		validateMaintenanceTimePeriod(ctx, path.Root("timeperiod").AtListIndex(i), period, &resp.Diagnostics)
+	}
+}
+
+// validateMaintenanceTimestamp parses an RFC3339 attribute; ok is false when it is unknown or invalid.
+func validateMaintenanceTimestamp(attr path.Path, value types.String, diags *diag.Diagnostics) (time.Time, bool) {
+	if value.IsNull() || value.IsUnknown() {
+		return time.Time{}, false
+	}
+	t, err := time.Parse(time.RFC3339, value.ValueString())
+	if err != nil {
+		diags.AddAttributeError(attr, "Invalid attribute value", fmt.Sprintf("%q is not an RFC3339 timestamp, e.g. `2026-10-20T22:00:00+02:00`.", value.ValueString()))
+		return time.Time{}, false
+	}
+	return t, true
+}
+
+func validateMaintenanceTimePeriod(ctx context.Context, periodPath path.Path, period maintenanceTimePeriodModel, diags *diag.Diagnostics) {
+	if !period.Period.IsUnknown() {
+		if seconds, err := parseMaintenanceDuration(period.Period.ValueString()); err != nil {
+			diags.AddAttributeError(periodPath.AtName("period"), "Invalid attribute value", err.Error())
+		} else if seconds < 300 {
+			diags.AddAttributeError(periodPath.AtName("period"), "Invalid attribute value", "`period` is at least 5 minutes.")
+		}
+	}
+	if !period.StartDate.IsNull() {
+		validateMaintenanceTimestamp(periodPath.AtName("start_date"), period.StartDate, diags)
+	}
+	if !period.StartTime.IsNull() && !period.StartTime.IsUnknown() && !maintenanceTimeOfDay.MatchString(period.StartTime.ValueString()) {
+		diags.AddAttributeError(periodPath.AtName("start_time"), "Invalid attribute value", fmt.Sprintf("%q is not a time of day, e.g. `22:30`.", period.StartTime.ValueString()))
+	}
+	for _, names := range []struct {
+		attr    string
+		value   types.Set
+		allowed []string
+	}{{"days_of_week", period.DaysOfWeek, maintenanceWeekdays}, {"months", period.Months, maintenanceMonths}} {
+		values, _ := setToStringsOptional(ctx, names.value)
+		for _, v := range values {
+			if nameToBit(names.allowed, v) == 0 {
+				diags.AddAttributeError(periodPath.AtName(names.attr), "Invalid attribute value", fmt.Sprintf("%q is not one of `%s` ... `%s`.", v, names.allowed[0], names.allowed[len(names.allowed)-1]))
+			}
+		}
+	}
+
+	if period.Type.IsUnknown() {
+		return
+	}
+	periodType := period.Type.ValueString()
+	set := map[string]bool{
+		"start_date":   !period.StartDate.IsNull(),
+		"start_time":   !period.StartTime.IsNull(),
+		"every":        !period.Every.IsNull(),
+		"days_of_week": !period.DaysOfWeek.IsNull(),
+		"months":       !period.Months.IsNull(),
+		"day":          !period.Day.IsNull(),
+	}
+	for _, attr := range maintenancePeriodAttributes {
+		if set[attr.name] && !slices.Contains(attr.types, periodType) {
+			diags.AddAttributeError(periodPath.AtName(attr.name), "Invalid attribute combination", fmt.Sprintf("`%s` is not used by `%s` periods.", attr.name, periodType))
+		}
+	}
+	required := map[string][]string{
+		"one_time": {"start_date"},
+		"daily":    {"start_time"},
+		"weekly":   {"start_time", "days_of_week"},
+		"monthly":  {"start_time", "months"},
+	}
+	for _, name := range required[periodType] {
+		if !set[name] {
+			diags.AddAttributeError(periodPath.AtName(name), "Missing attribute", fmt.Sprintf("`%s` periods require `%s`.", periodType, name))
+		}
+	}
+	if periodType == "monthly" && set["day"] == set["days_of_week"] {
+		diags.AddAttributeError(periodPath.AtName("day"), "Invalid attribute combination", "`monthly` periods take either `day` or `days_of_week`.")
+	}
+	if periodType == "monthly" && set["every"] && set["day"] {
+		diags.AddAttributeError(periodPath.AtName("every"), "Invalid attribute combination", "`every` is only used by `monthly` periods with `days_of_week`.")
+	}
+	if every := period.Every; !every.IsNull() && !every.IsUnknown() {
+		if every.ValueInt64() < 1 || (periodType == "monthly" && every.ValueInt64() > 5) {
+			diags.AddAttributeError(periodPath.AtName("every"), "Invalid attribute value", "`every` is at least 1, and at most 5 for `monthly` periods.")
+		}
+	}
+	if day := period.Day; !day.IsNull() && !day.IsUnknown() && (day.ValueInt64() < 1 || day.ValueInt64() > 31) {
+		diags.AddAttributeError(periodPath.AtName("day"), "Invalid attribute value", "`day` is between 1 and 31.")
+	}
+}
+
+func (r *maintenanceResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan maintenanceResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	zreq, d := expandMaintenance(ctx, plan)
+	resp.Diagnostics.Append(d...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	id, err := r.client.MaintenanceCreate(ctx, zreq)
+	if err != nil {
+		resp.Diagnostics.AddError("maintenance.create error", err.Error())
+		return
+	}
+
+	plan.ID = types.StringValue(id)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *maintenanceResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state maintenanceResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	maintenance, err := r.client.MaintenanceGetByID(ctx, state.ID.ValueString())
+	if err != nil {
+		if zabbix.IsNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError("maintenance.get error", err.Error())
+		return
+	}
+
+	state.Name = types.StringValue(maintenance.Name)
+	state.Description = nullOrString(maintenance.Description)
+	state.DataCollection = types.BoolValue(maintenance.MaintenanceType == 0)
+	state.ActiveSince = flattenTimestamp(int64(maintenance.ActiveSince), state.ActiveSince)
+	state.ActiveTill = flattenTimestamp(int64(maintenance.ActiveTill), state.ActiveTill)
+	state.TagsEvalType = types.StringValue(codeToName(maintenanceTagsEvalTypes, strconv.Itoa(int(maintenance.TagsEvalType)), "and_or"))
+
+	hostIDs := make([]string, 0, len(maintenance.Hosts))
+	for _, h := range maintenance.Hosts {
+		hostIDs = append(hostIDs, h.HostID)
+	}
+	if len(hostIDs) > 0 || !state.HostIDs.IsNull() {
+		state.HostIDs, _ = types.SetValueFrom(ctx, types.StringType, hostIDs)
+	}
+	groupIDs := make([]string, 0, len(maintenance.HostGroups))
+	for _, g := range maintenance.HostGroups {
+		groupIDs = append(groupIDs, g.GroupID)
+	}
+	if len(groupIDs) > 0 || !state.HostGroupIDs.IsNull() {
+		state.HostGroupIDs, _ = types.SetValueFrom(ctx, types.StringType, groupIDs)
+	}
+
+	state.Tags = make([]maintenanceTagModel, 0, len(maintenance.Tags))
+	for _, tag := range maintenance.Tags {
+		state.Tags = append(state.Tags, maintenanceTagModel{
+			Tag:      types.StringValue(tag.Tag),
+			Operator: types.StringValue(codeToName(maintenanceTagOperators, strconv.Itoa(int(tag.Operator)), "contains")),
+			Value:    types.StringValue(tag.Value),
+		})
+	}
+
+	prior := state.TimePeriods
+	state.TimePeriods = make([]maintenanceTimePeriodModel, 0, len(maintenance.TimePeriods))
+	for i, period := range maintenance.TimePeriods {
+		var priorPeriod *maintenanceTimePeriodModel
+		if i < len(prior) {
+			priorPeriod = &prior[i]
+		}
+		state.TimePeriods = append(state.TimePeriods, flattenMaintenanceTimePeriod(ctx, period, priorPeriod))
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *maintenanceResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan maintenanceResourceModel
+	var state maintenanceResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	zreq, d := expandMaintenance(ctx, plan)
+	resp.Diagnostics.Append(d...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if err := r.client.MaintenanceUpdate(ctx, state.ID.ValueString(), zreq); err != nil {
+		resp.Diagnostics.AddError("maintenance.update error", err.Error())
+		return
+	}
+
+	plan.ID = state.ID
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *maintenanceResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state maintenanceResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	err := r.client.MaintenanceDelete(ctx, state.ID.ValueString())
+	if err != nil && !zabbix.IsNotFound(err) {
+		resp.Diagnostics.AddError("maintenance.delete error", err.Error())
+	}
+}
+
+func (r *maintenanceResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+// expandMaintenance builds the maintenance.create/update request from the plan.
+func expandMaintenance(ctx context.Context, plan maintenanceResourceModel) (zabbix.MaintenanceCreateRequest, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	hostIDs, d := setToStringsOptional(ctx, plan.HostIDs)
+	diags.Append(d...)
+	groupIDs, d := setToStringsOptional(ctx, plan.HostGroupIDs)
+	diags.Append(d...)
+	since, _ := time.Parse(time.RFC3339, plan.ActiveSince.ValueString())
+	till, _ := time.Parse(time.RFC3339, plan.ActiveTill.ValueString())
+
+	req := zabbix.MaintenanceCreateRequest{
+		Name:            plan.Name.ValueString(),
+		Description:     nullableString(plan.Description),
+		MaintenanceType: zabbix.BoolToInt(!plan.DataCollection.ValueBool()),
+		ActiveSince:     since.Unix(),
+		ActiveTill:      till.Unix(),
+		TagsEvalType:    int(atoi64(nameToCode(maintenanceTagsEvalTypes, plan.TagsEvalType.ValueString()))),
+		HostIDs:         hostIDs,
+		GroupIDs:        groupIDs,
+	}
+	for _, tag := range plan.Tags {
+		req.Tags = append(req.Tags, zabbix.MaintenanceTag{
+			Tag:      tag.Tag.ValueString(),
+			Operator: zabbix.FlexInt(atoi64(nameToCode(maintenanceTagOperators, tag.Operator.ValueString()))),
+			Value:    tag.Value.ValueString(),
+		})
+	}
+	for _, period := range plan.TimePeriods {
+		seconds, _ := parseMaintenanceDuration(period.Period.ValueString())
+		zperiod := zabbix.MaintenanceTimePeriod{
+			Type:   zabbix.FlexInt(atoi64(nameToCode(maintenancePeriodTypes, period.Type.ValueString()))),
+			Every:  zabbix.FlexInt(period.Every.ValueInt64()),
+			Day:    zabbix.FlexInt(period.Day.ValueInt64()),
+			Period: zabbix.FlexInt(seconds),
+		}
+		if !period.StartDate.IsNull() {
+			startDate, _ := time.Parse(time.RFC3339, period.StartDate.ValueString())
+			zperiod.StartDate = zabbix.FlexInt(startDate.Unix())
+		}
+		if m := maintenanceTimeOfDay.FindStringSubmatch(period.StartTime.ValueString()); m != nil {
+			zperiod.StartTime = zabbix.FlexInt(atoi64(m[1])*3600 + atoi64(m[2])*60)
+		}
+		days, d := setToStringsOptional(ctx, period.DaysOfWeek)
+		diags.Append(d...)
+		for _, day := range days {
+			zperiod.DayOfWeek |= zabbix.FlexInt(nameToBit(maintenanceWeekdays, day))
+		}
+		months, d := setToStringsOptional(ctx, period.Months)
+		diags.Append(d...)
+		for _, month := range months {
+			zperiod.Month |= zabbix.FlexInt(nameToBit(maintenanceMonths, month))
+		}
+		req.TimePeriods = append(req.TimePeriods, zperiod)
+	}
+	return req, diags
+}
+
+// flattenMaintenanceTimePeriod converts a period read from the API, keeping the notation of the prior state
+// (time zone of start_date, unit of period) when the values are the same.
+func flattenMaintenanceTimePeriod(ctx context.Context, period zabbix.MaintenanceTimePeriod, prior *maintenanceTimePeriodModel) maintenanceTimePeriodModel {
+	periodType := codeToName(maintenancePeriodTypes, strconv.Itoa(int(period.Type)), "one_time")
+	model := maintenanceTimePeriodModel{
+		Type:       types.StringValue(periodType),
+		Period:     types.StringValue(formatMaintenanceDuration(int64(period.Period))),
+		StartDate:  types.StringNull(),
+		StartTime:  types.StringNull(),
+		Every:      types.Int64Value(1),
+		DaysOfWeek: types.SetNull(types.StringType),
+		Months:     types.SetNull(types.StringType),
+		Day:        types.Int64Null(),
+	}
+	if prior != nil {
+		if seconds, err := parseMaintenanceDuration(prior.Period.ValueString()); err == nil && seconds == int64(period.Period) {
+			model.Period = prior.Period
+		}
+	}
+
+	switch period.Type {
+	case zabbix.MaintenancePeriodOneTime:
+		priorDate := types.StringNull()
+		if prior != nil {
+			priorDate = prior.StartDate
+		}
+		model.StartDate = flattenTimestamp(int64(period.StartDate), priorDate)
+		return model
+	case zabbix.MaintenancePeriodMonthly:
+		model.Months = stringsToSetOrNull(ctx, bitsToNames(maintenanceMonths, int64(period.Month)))
+		if period.DayOfWeek == 0 {
+			model.Day = types.Int64Value(int64(period.Day))
+		} else {
+			model.Every = types.Int64Value(int64(period.Every))
+		}
+	default:
+		model.Every = types.Int64Value(int64(period.Every))
+	}
+	if period.Type != zabbix.MaintenancePeriodDaily {
+		model.DaysOfWeek = stringsToSetOrNull(ctx, bitsToNames(maintenanceWeekdays, int64(period.DayOfWeek)))
+	}
+	model.StartTime = types.StringValue(fmt.Sprintf("%02d:%02d", period.StartTime/3600, period.StartTime%3600/60))
+	if prior != nil && prior.StartTime.ValueString() != "" {
+		if m := maintenanceTimeOfDay.FindStringSubmatch(prior.StartTime.ValueString()); m != nil && atoi64(m[1])*3600+atoi64(m[2])*60 == int64(period.StartTime) {
+			model.StartTime = prior.StartTime
+		}
+	}
+	return model
+}
+
+// flattenTimestamp formats a Unix time as RFC3339, keeping the prior value when it is the same instant.
+func flattenTimestamp(unix int64, prior types.String) types.String {
+	if !prior.IsNull() && !prior.IsUnknown() {
+		if t, err := time.Parse(time.RFC3339, prior.ValueString()); err == nil && t.Unix() == unix {
+			return prior
+		}
+	}
+	return types.StringValue(time.Unix(unix, 0).UTC().Format(time.RFC3339))
+}
+
+// parseMaintenanceDuration converts a duration with an optional s, m, h, d or w suffix to seconds.
+func parseMaintenanceDuration(value string) (int64, error) {
+	m := maintenanceDuration.FindStringSubmatch(value)
+	if m == nil {
+		return 0, fmt.Errorf("%q is not a duration, e.g. `3600`, `90m`, `2h` or `1d`.", value)
+	}
+	multiplier := map[string]int64{"": 1, "s": 1, "m": 60, "h": 3600, "d": 86400, "w": 604800}[m[2]]
+	return atoi64(m[1]) * multiplier, nil
+}
+
+// formatMaintenanceDuration formats seconds with the largest suffix that divides them.
+func formatMaintenanceDuration(seconds int64) string {
+	for _, unit := range []struct {
+		suffix  string
+		seconds int64
+	}{{"w", 604800}, {"d", 86400}, {"h", 3600}, {"m", 60}} {
+		if seconds > 0 && seconds%unit.seconds == 0 {
+			return strconv.FormatInt(seconds/unit.seconds, 10) + unit.suffix
+		}
+	}
+	return strconv.FormatInt(seconds, 10)
+}
+
+// nameToBit returns the bitmask bit of a name indexed by bit position; 0 if unknown.
+func nameToBit(names []string, name string) int64 {
+	for i, n := range names {
+		if n == name {
+			return 1 << i
+		}
+	}
+	return 0
+}
+
+// bitsToNames returns the names of the bits set in a bitmask.
+func bitsToNames(names []string, bits int64) []string {
+	var out []string
+	for i, n := range names {
+		if bits&(1<<i) != 0 {
+			out = append(out, n)
+		}
+	}
+	return out
+}
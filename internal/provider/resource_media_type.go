@@ -0,0 +1,533 @@
+package provider
+
+import (
+	"context"
+	"sort"
+	"strconv"
+
+	"github.com/rushiii/terraform-provider-zabbix/internal/zabbix"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Media type kinds, event sources and message kinds exposed in the schema, indexed by Zabbix code.
+var (
+	mediaTypeKinds        = []string{"email", "script", "sms", "", "webhook"}
+	mediaTypeSMTPSecurity = []string{"none", "starttls", "ssl"}
+	eventSourceNames      = []string{"trigger", "discovery", "autoregistration", "internal", "service"}
+	messageRecoveryNames  = []string{"problem", "recovery", "update"}
+)
+
+var (
+	_ resource.Resource                = &mediaTypeResource{}
+	_ resource.ResourceWithConfigure   = &mediaTypeResource{}
+	_ resource.ResourceWithImportState = &mediaTypeResource{}
+)
+
+type mediaTypeResource struct {
+	client *zabbix.Client
+}
+
+type mediaTypeResourceModel struct {
+	ID               types.String                    `tfsdk:"id"`
+	Name             types.String                    `tfsdk:"name"`
+	Type             types.String                    `tfsdk:"type"`
+	Enabled          types.Bool                      `tfsdk:"enabled"`
+	Description      types.String                    `tfsdk:"description"`
+	MaxSessions      types.Int64                     `tfsdk:"max_sessions"`
+	MaxAttempts      types.Int64                     `tfsdk:"max_attempts"`
+	AttemptInterval  types.String                    `tfsdk:"attempt_interval"`
+	SMTPServer       types.String                    `tfsdk:"smtp_server"`
+	SMTPPort         types.Int64                     `tfsdk:"smtp_port"`
+	SMTPHelo         types.String                    `tfsdk:"smtp_helo"`
+	SMTPEmail        types.String                    `tfsdk:"smtp_email"`
+	SMTPSecurity     types.String                    `tfsdk:"smtp_security"`
+	SMTPVerifyPeer   types.Bool                      `tfsdk:"smtp_verify_peer"`
+	SMTPVerifyHost   types.Bool                      `tfsdk:"smtp_verify_host"`
+	Username         types.String                    `tfsdk:"username"`
+	Password         types.String                    `tfsdk:"password"`
+	ContentType      types.String                    `tfsdk:"content_type"`
+	ExecPath         types.String                    `tfsdk:"exec_path"`
+	ScriptParameters types.List                      `tfsdk:"script_parameters"`
+	GSMModem         types.String                    `tfsdk:"gsm_modem"`
+	Script           types.String                    `tfsdk:"script"`
+	Timeout          types.String                    `tfsdk:"timeout"`
+	ProcessTags      types.Bool                      `tfsdk:"process_tags"`
+	ShowEventMenu    types.Bool                      `tfsdk:"show_event_menu"`
+	EventMenuURL     types.String                    `tfsdk:"event_menu_url"`
+	EventMenuName    types.String                    `tfsdk:"event_menu_name"`
+	WebhookParams    types.Map                       `tfsdk:"webhook_parameters"`
+	MessageTemplates []mediaTypeMessageTemplateModel `tfsdk:"message_template"`
+}
+
+type mediaTypeMessageTemplateModel struct {
+	EventSource types.String `tfsdk:"event_source"`
+	Recovery    types.String `tfsdk:"recovery"`
+	Subject     types.String `tfsdk:"subject"`
+	Message     types.String `tfsdk:"message"`
+}
+
+func NewMediaTypeResource() resource.Resource {
+	return &mediaTypeResource{}
+}
+
+func (r *mediaTypeResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_media_type"
+}
+
+func (r *mediaTypeResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Zabbix media type (email, script, SMS or webhook) used to deliver notifications.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Media type name.",
+			},
+			"type": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Kind of media type: `email`, `script`, `sms` or `webhook`.",
+				Validators: []validator.String{
+					stringOneOf("email", "script", "sms", "webhook"),
+				},
+			},
+			"enabled": schema.BoolAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(true),
+				MarkdownDescription: "Whether the media type is enabled.",
+			},
+			"description": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Description.",
+			},
+			"max_sessions": schema.Int64Attribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             int64default.StaticInt64(1),
+				MarkdownDescription: "Maximum concurrent alerts (0 = unlimited, must be 1 for SMS).",
+			},
+			"max_attempts": schema.Int64Attribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             int64default.StaticInt64(3),
+				MarkdownDescription: "Maximum delivery attempts (1-100).",
+			},
+			"attempt_interval": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString("10s"),
+				MarkdownDescription: "Interval between delivery attempts (e.g. `10s`, up to `1h`).",
+			},
+
+			// Email
+			"smtp_server": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Email: SMTP server.",
+			},
+			"smtp_port": schema.Int64Attribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             int64default.StaticInt64(25),
+				MarkdownDescription: "Email: SMTP port.",
+			},
+			"smtp_helo": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Email: SMTP HELO.",
+			},
+			"smtp_email": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Email: sender address.",
+			},
+			"smtp_security": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString("none"),
+				MarkdownDescription: "Email: connection security, `none`, `starttls` or `ssl`.",
+				Validators: []validator.String{
+					stringOneOf(mediaTypeSMTPSecurity...),
+				},
+			},
+			"smtp_verify_peer": schema.BoolAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+				MarkdownDescription: "Email: verify the SMTP server certificate (with `starttls`/`ssl`).",
+			},
+			"smtp_verify_host": schema.BoolAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+				MarkdownDescription: "Email: verify the SMTP server host name (with `starttls`/`ssl`).",
+			},
+			"username": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Email: SMTP user name. Setting it enables SMTP authentication.",
+			},
+			"password": schema.StringAttribute{
+				Optional:            true,
+				Sensitive:           true,
+				MarkdownDescription: "Email: SMTP password. Never returned by Zabbix; kept from the configuration.",
+			},
+			"content_type": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString("html"),
+				MarkdownDescription: "Email: message format, `html` or `plain`.",
+				Validators: []validator.String{
+					stringOneOf("html", "plain"),
+				},
+			},
+
+			// Script
+			"exec_path": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Script: file name of the alert script in AlertScriptsPath.",
+			},
+			"script_parameters": schema.ListAttribute{
+				Optional:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "Script: command-line parameters, in order (macros such as `{ALERT.SENDTO}` are supported).",
+			},
+
+			// SMS
+			"gsm_modem": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "SMS: serial device of the GSM modem (e.g. `/dev/ttyS0`).",
+			},
+
+			// Webhook
+			"script": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Webhook: JavaScript body.",
+			},
+			"timeout": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString("30s"),
+				MarkdownDescription: "Webhook: JavaScript execution timeout (1-60s).",
+			},
+			"process_tags": schema.BoolAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+				MarkdownDescription: "Webhook: process the returned JSON `tags` property.",
+			},
+			"show_event_menu": schema.BoolAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+				MarkdownDescription: "Webhook: add an entry to the event menu.",
+			},
+			"event_menu_url": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Webhook: URL of the event menu entry.",
+			},
+			"event_menu_name": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Webhook: name of the event menu entry.",
+			},
+			"webhook_parameters": schema.MapAttribute{
+				Optional:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "Webhook: parameters passed to the JavaScript (name => value).",
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"message_template": schema.SetNestedBlock{
+				MarkdownDescription: "Default message for an event source and problem/recovery/update kind.",
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"event_source": schema.StringAttribute{
+							Required:            true,
+							MarkdownDescription: "`trigger`, `discovery`, `autoregistration`, `internal` or `service`.",
+							Validators: []validator.String{
+								stringOneOf(eventSourceNames...),
+							},
+						},
+						"recovery": schema.StringAttribute{
+							Optional:            true,
+							Computed:            true,
+							Default:             stringdefault.StaticString("problem"),
+							MarkdownDescription: "`problem`, `recovery` or `update`.",
+							Validators: []validator.String{
+								stringOneOf(messageRecoveryNames...),
+							},
+						},
+						"subject": schema.StringAttribute{
+							Optional:            true,
+							Computed:            true,
+							Default:             stringdefault.StaticString(""),
+							MarkdownDescription: "Message subject.",
+						},
+						"message": schema.StringAttribute{
+							Optional:            true,
+							Computed:            true,
+							Default:             stringdefault.StaticString(""),
+							MarkdownDescription: "Message body.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (r *mediaTypeResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	providerData, ok := req.ProviderData.(*providerData)
+	if !ok || providerData.Client == nil {
+		resp.Diagnostics.AddError("Invalid provider", "Zabbix client unavailable.")
+		return
+	}
+	r.client = providerData.Client
+}
+
+func (r *mediaTypeResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan mediaTypeResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	mt, d := expandMediaType(ctx, plan)
+	resp.Diagnostics.Append(d...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	id, err := r.client.MediaTypeCreate(ctx, mt)
+	if err != nil {
+		resp.Diagnostics.AddError("mediatype.create error", err.Error())
+		return
+	}
+	plan.ID = types.StringValue(id)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *mediaTypeResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state mediaTypeResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	mt, err := r.client.MediaTypeGetByID(ctx, state.ID.ValueString())
+	if err != nil {
+		if zabbix.IsNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError("mediatype.get error", err.Error())
+		return
+	}
+
+	state.Name = types.StringValue(mt.Name)
+	state.Type = types.StringValue(codeToName(mediaTypeKinds, mt.Type, "email"))
+	state.Enabled = types.BoolValue(zabbix.StatusToEnabled(mt.Status))
+	state.Description = nullOrString(mt.Description)
+	state.MaxSessions = types.Int64Value(atoi64(mt.MaxSessions))
+	state.MaxAttempts = types.Int64Value(atoi64(mt.MaxAttempts))
+	state.AttemptInterval = types.StringValue(mt.AttemptInterval)
+
+	// Only fields of the media type kind are read back; Zabbix fills the others with defaults.
+	switch state.Type.ValueString() {
+	case "email":
+		state.SMTPServer = nullOrString(mt.SMTPServer)
+		state.SMTPPort = types.Int64Value(atoi64(mt.SMTPPort))
+		state.SMTPHelo = nullOrString(mt.SMTPHelo)
+		state.SMTPEmail = nullOrString(mt.SMTPEmail)
+		state.SMTPSecurity = types.StringValue(codeToName(mediaTypeSMTPSecurity, mt.SMTPSecurity, "none"))
+		if mt.SMTPSecurity != "0" {
+			state.SMTPVerifyPeer = types.BoolValue(mt.SMTPVerifyPeer == "1")
+			state.SMTPVerifyHost = types.BoolValue(mt.SMTPVerifyHost == "1")
+		}
+		if mt.SMTPAuthentication == "1" {
+			state.Username = nullOrString(mt.Username)
+		} else {
+			state.Username = types.StringNull()
+		}
+		if mt.ContentType == "0" {
+			state.ContentType = types.StringValue("plain")
+		} else {
+			state.ContentType = types.StringValue("html")
+		}
+	case "script":
+		state.ExecPath = nullOrString(mt.ExecPath)
+		params := append([]zabbix.MediaTypeParameter(nil), mt.Parameters...)
+		sort.SliceStable(params, func(i, j int) bool {
+			return mediaTypeParamOrder(params[i]) < mediaTypeParamOrder(params[j])
+		})
+		values := make([]string, 0, len(params))
+		for _, p := range params {
+			values = append(values, p.Value)
+		}
+		if len(values) > 0 {
+			state.ScriptParameters, _ = types.ListValueFrom(ctx, types.StringType, values)
+		} else {
+			state.ScriptParameters = types.ListNull(types.StringType)
+		}
+	case "sms":
+		state.GSMModem = nullOrString(mt.GSMModem)
+	case "webhook":
+		state.Script = nullOrString(mt.Script)
+		state.Timeout = types.StringValue(mt.Timeout)
+		state.ProcessTags = types.BoolValue(mt.ProcessTags == "1")
+		state.ShowEventMenu = types.BoolValue(mt.ShowEventMenu == "1")
+		state.EventMenuURL = nullOrString(mt.EventMenuURL)
+		state.EventMenuName = nullOrString(mt.EventMenuName)
+		if len(mt.Parameters) > 0 {
+			params := make(map[string]string, len(mt.Parameters))
+			for _, p := range mt.Parameters {
+				params[p.Name] = p.Value
+			}
+			state.WebhookParams, _ = types.MapValueFrom(ctx, types.StringType, params)
+		} else {
+			state.WebhookParams = types.MapNull(types.StringType)
+		}
+	}
+
+	state.MessageTemplates = make([]mediaTypeMessageTemplateModel, 0, len(mt.MessageTemplates))
+	for _, t := range mt.MessageTemplates {
+		state.MessageTemplates = append(state.MessageTemplates, mediaTypeMessageTemplateModel{
+			EventSource: types.StringValue(codeToName(eventSourceNames, t.EventSource, "trigger")),
+			Recovery:    types.StringValue(codeToName(messageRecoveryNames, t.Recovery, "problem")),
+			Subject:     types.StringValue(t.Subject),
+			Message:     types.StringValue(t.Message),
+		})
+	}
+	// password is not returned by API; keep from state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *mediaTypeResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan mediaTypeResourceModel
+	var state mediaTypeResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	mt, d := expandMediaType(ctx, plan)
+	resp.Diagnostics.Append(d...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if err := r.client.MediaTypeUpdate(ctx, state.ID.ValueString(), mt); err != nil {
+		resp.Diagnostics.AddError("mediatype.update error", err.Error())
+		return
+	}
+	plan.ID = state.ID
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *mediaTypeResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state mediaTypeResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	err := r.client.MediaTypeDelete(ctx, state.ID.ValueString())
+	if err != nil && !zabbix.IsNotFound(err) {
+		resp.Diagnostics.AddError("mediatype.delete error", err.Error())
+	}
+}
+
+func (r *mediaTypeResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+func expandMediaType(ctx context.Context, plan mediaTypeResourceModel) (zabbix.MediaType, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	mt := zabbix.MediaType{
+		Name:            plan.Name.ValueString(),
+		Type:            nameToCode(mediaTypeKinds, plan.Type.ValueString()),
+		Status:          strconv.Itoa(zabbix.BoolToStatus(plan.Enabled.ValueBool())),
+		Description:     nullableString(plan.Description),
+		MaxSessions:     strconv.FormatInt(plan.MaxSessions.ValueInt64(), 10),
+		MaxAttempts:     strconv.FormatInt(plan.MaxAttempts.ValueInt64(), 10),
+		AttemptInterval: plan.AttemptInterval.ValueString(),
+
+		SMTPServer:         nullableString(plan.SMTPServer),
+		SMTPPort:           strconv.FormatInt(plan.SMTPPort.ValueInt64(), 10),
+		SMTPHelo:           nullableString(plan.SMTPHelo),
+		SMTPEmail:          nullableString(plan.SMTPEmail),
+		SMTPSecurity:       nameToCode(mediaTypeSMTPSecurity, plan.SMTPSecurity.ValueString()),
+		SMTPVerifyPeer:     strconv.Itoa(zabbix.BoolToInt(plan.SMTPVerifyPeer.ValueBool())),
+		SMTPVerifyHost:     strconv.Itoa(zabbix.BoolToInt(plan.SMTPVerifyHost.ValueBool())),
+		SMTPAuthentication: strconv.Itoa(zabbix.BoolToInt(nullableString(plan.Username) != "")),
+		Username:           nullableString(plan.Username),
+		Passwd:             nullableString(plan.Password),
+		ContentType:        "1",
+
+		ExecPath: nullableString(plan.ExecPath),
+		GSMModem: nullableString(plan.GSMModem),
+
+		Script:        nullableString(plan.Script),
+		Timeout:       plan.Timeout.ValueString(),
+		ProcessTags:   strconv.Itoa(zabbix.BoolToInt(plan.ProcessTags.ValueBool())),
+		ShowEventMenu: strconv.Itoa(zabbix.BoolToInt(plan.ShowEventMenu.ValueBool())),
+		EventMenuURL:  nullableString(plan.EventMenuURL),
+		EventMenuName: nullableString(plan.EventMenuName),
+	}
+	if plan.ContentType.ValueString() == "plain" {
+		mt.ContentType = "0"
+	}
+
+	switch plan.Type.ValueString() {
+	case "script":
+		if !plan.ScriptParameters.IsNull() && !plan.ScriptParameters.IsUnknown() {
+			var values []string
+			diags.Append(plan.ScriptParameters.ElementsAs(ctx, &values, false)...)
+			for i, v := range values {
+				order := zabbix.FlexIntFrom(i)
+				mt.Parameters = append(mt.Parameters, zabbix.MediaTypeParameter{SortOrder: &order, Value: v})
+			}
+		}
+	case "webhook":
+		if !plan.WebhookParams.IsNull() && !plan.WebhookParams.IsUnknown() {
+			values := map[string]string{}
+			diags.Append(plan.WebhookParams.ElementsAs(ctx, &values, false)...)
+			names := make([]string, 0, len(values))
+			for name := range values {
+				names = append(names, name)
+			}
+			sort.Strings(names)
+			for _, name := range names {
+				mt.Parameters = append(mt.Parameters, zabbix.MediaTypeParameter{Name: name, Value: values[name]})
+			}
+		}
+	}
+
+	for _, t := range plan.MessageTemplates {
+		mt.MessageTemplates = append(mt.MessageTemplates, zabbix.MediaTypeMessageTemplate{
+			EventSource: nameToCode(eventSourceNames, t.EventSource.ValueString()),
+			Recovery:    nameToCode(messageRecoveryNames, t.Recovery.ValueString()),
+			Subject:     t.Subject.ValueString(),
+			Message:     t.Message.ValueString(),
+		})
+	}
+	return mt, diags
+}
+
+func mediaTypeParamOrder(p zabbix.MediaTypeParameter) int {
+	if p.SortOrder == nil {
+		return 0
+	}
+	return int(*p.SortOrder)
+}
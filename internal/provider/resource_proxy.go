@@ -0,0 +1,459 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/rushiii/terraform-provider-zabbix/internal/zabbix"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ resource.Resource                   = &proxyResource{}
+	_ resource.ResourceWithConfigure      = &proxyResource{}
+	_ resource.ResourceWithImportState    = &proxyResource{}
+	_ resource.ResourceWithValidateConfig = &proxyResource{}
+)
+
+var (
+	proxyModes = []string{zabbix.ProxyModeActive: "active", zabbix.ProxyModePassive: "passive"}
+	// tlsConnectionTypes is indexed by the tls_connect code and tls_accept bit.
+	tlsConnectionTypes = []string{
+		zabbix.TLSNoEncryption: "no_encryption",
+		zabbix.TLSPSK:          "psk",
+		zabbix.TLSCertificate:  "certificate",
+	}
+	// tlsPSKValue matches pre-shared keys: 32 to 512 hex digits.
+	tlsPSKValue = regexp.MustCompile(`^[0-9a-fA-F]{32,512}$`)
+)
+
+type proxyResource struct {
+	client *zabbix.Client
+}
+
+type proxyResourceModel struct {
+	ID               types.String `tfsdk:"id"`
+	Name             types.String `tfsdk:"name"`
+	Mode             types.String `tfsdk:"mode"`
+	Description      types.String `tfsdk:"description"`
+	Address          types.String `tfsdk:"address"`
+	Port             types.String `tfsdk:"port"`
+	AllowedAddresses types.Set    `tfsdk:"allowed_addresses"`
+	ProxyGroupID     types.String `tfsdk:"proxy_group_id"`
+	LocalAddress     types.String `tfsdk:"local_address"`
+	LocalPort        types.String `tfsdk:"local_port"`
+	TLSConnect       types.String `tfsdk:"tls_connect"`
+	TLSAccept        types.Set    `tfsdk:"tls_accept"`
+	TLSPSKIdentity   types.String `tfsdk:"tls_psk_identity"`
+	TLSPSK           types.String `tfsdk:"tls_psk"`
+	TLSIssuer        types.String `tfsdk:"tls_issuer"`
+	TLSSubject       types.String `tfsdk:"tls_subject"`
+}
+
+func NewProxyResource() resource.Resource {
+	return &proxyResource{}
+}
+
+func (r *proxyResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_proxy"
+}
+
+func (r *proxyResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	attributes := map[string]schema.Attribute{
+		"id": schema.StringAttribute{
+			Computed: true,
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.UseStateForUnknown(),
+			},
+			MarkdownDescription: "Internal Zabbix ID.",
+		},
+		"name": schema.StringAttribute{
+			Required:            true,
+			MarkdownDescription: "Proxy name, as configured in the `Hostname` of the proxy.",
+		},
+		"mode": schema.StringAttribute{
+			Optional:            true,
+			Computed:            true,
+			Default:             stringdefault.StaticString("active"),
+			MarkdownDescription: "`active` (the proxy connects to the server) or `passive` (the server connects to the proxy). Default: `active`.",
+			Validators: []validator.String{
+				stringOneOf("active", "passive"),
+			},
+		},
+		"description": schema.StringAttribute{
+			Optional: true,
+		},
+		"address": schema.StringAttribute{
+			Optional:            true,
+			MarkdownDescription: "IP address or DNS name of a `passive` proxy.",
+		},
+		"port": schema.StringAttribute{
+			Optional:            true,
+			Computed:            true,
+			Default:             stringdefault.StaticString("10051"),
+			MarkdownDescription: "Port of a `passive` proxy. Default: `10051`.",
+		},
+		"allowed_addresses": schema.SetAttribute{
+			Optional:            true,
+			ElementType:         types.StringType,
+			MarkdownDescription: "IP addresses, CIDR ranges or DNS names an `active` proxy may connect from. Empty accepts any.",
+		},
+		"proxy_group_id": schema.StringAttribute{
+			Optional:            true,
+			MarkdownDescription: "ID of the proxy group of the proxy (Zabbix 7.0+).",
+		},
+		"local_address": schema.StringAttribute{
+			Optional:            true,
+			MarkdownDescription: "Address agents use to reach the proxy within its proxy group. Required with `proxy_group_id`.",
+		},
+		"local_port": schema.StringAttribute{
+			Optional:            true,
+			Computed:            true,
+			Default:             stringdefault.StaticString("10051"),
+			MarkdownDescription: "Port agents use to reach the proxy within its proxy group. Default: `10051`.",
+		},
+	}
+	for name, attribute := range tlsAttributes("proxy") {
+		attributes[name] = attribute
+	}
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Zabbix proxy resource.",
+		Attributes:          attributes,
+	}
+}
+
+// tlsAttributes are the TLS settings of the connections between the server and a proxy or host.
+func tlsAttributes(object string) map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"tls_connect": schema.StringAttribute{
+			Optional:            true,
+			Computed:            true,
+			Default:             stringdefault.StaticString("no_encryption"),
+			MarkdownDescription: fmt.Sprintf("Connections to the %s: `no_encryption`, `psk` or `certificate`. Default: `no_encryption`.", object),
+			Validators: []validator.String{
+				stringOneOf("no_encryption", "psk", "certificate"),
+			},
+		},
+		"tls_accept": schema.SetAttribute{
+			Optional:            true,
+			Computed:            true,
+			ElementType:         types.StringType,
+			Default:             setdefault.StaticValue(types.SetValueMust(types.StringType, []attr.Value{types.StringValue("no_encryption")})),
+			MarkdownDescription: fmt.Sprintf("Connections accepted from the %s: any of `no_encryption`, `psk` and `certificate`. Default: `[\"no_encryption\"]`.", object),
+		},
+		"tls_psk_identity": schema.StringAttribute{
+			Optional:            true,
+			MarkdownDescription: "PSK identity. Required when `psk` is used.",
+		},
+		"tls_psk": schema.StringAttribute{
+			Optional:            true,
+			Sensitive:           true,
+			MarkdownDescription: "Pre-shared key, 32 to 512 hex digits. Required when `psk` is used. Zabbix never returns it, so changes made outside Terraform are not detected.",
+		},
+		"tls_issuer": schema.StringAttribute{
+			Optional:            true,
+			MarkdownDescription: "Allowed certificate issuer.",
+		},
+		"tls_subject": schema.StringAttribute{
+			Optional:            true,
+			MarkdownDescription: "Allowed certificate subject.",
+		},
+	}
+}
+
+func (r *proxyResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	providerData, ok := req.ProviderData.(*providerData)
+	if !ok || providerData.Client == nil {
+		resp.Diagnostics.AddError("Invalid provider", "Zabbix client unavailable.")
+		return
+	}
+	r.client = providerData.Client
+}
+
+func (r *proxyResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config proxyResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !config.Mode.IsUnknown() {
+		passive := config.Mode.ValueString() == "passive"
+		if passive && config.Address.IsNull() {
+			resp.Diagnostics.AddAttributeError(path.Root("address"), "Missing attribute", "`passive` proxies require `address`.")
+		}
+		if !passive && !config.Address.IsNull() {
+			resp.Diagnostics.AddAttributeError(path.Root("address"), "Invalid attribute combination", "`address` is only used by `passive` proxies.")
+		}
+		if passive && !config.AllowedAddresses.IsNull() {
+			resp.Diagnostics.AddAttributeError(path.Root("allowed_addresses"), "Invalid attribute combination", "`allowed_addresses` is only used by `active` proxies.")
+		}
+		if !passive && !config.TLSConnect.IsNull() && !config.TLSConnect.IsUnknown() && config.TLSConnect.ValueString() != "no_encryption" {
+			resp.Diagnostics.AddAttributeError(path.Root("tls_connect"), "Invalid attribute combination", "The server does not connect to `active` proxies; use `tls_accept`.")
+		}
+		if accept, _ := setToStringsOptional(ctx, config.TLSAccept); passive && slices.ContainsFunc(accept, func(v string) bool { return v != "no_encryption" }) {
+			resp.Diagnostics.AddAttributeError(path.Root("tls_accept"), "Invalid attribute combination", "`passive` proxies do not connect to the server; use `tls_connect`.")
+		}
+	}
+	if !config.ProxyGroupID.IsNull() && config.LocalAddress.IsNull() {
+		resp.Diagnostics.AddAttributeError(path.Root("local_address"), "Missing attribute", "Proxies of a proxy group require `local_address`.")
+	}
+	if config.ProxyGroupID.IsNull() && !config.LocalAddress.IsNull() {
+		resp.Diagnostics.AddAttributeError(path.Root("local_address"), "Invalid attribute combination", "`local_address` is only used with `proxy_group_id`.")
+	}
+	validateTLS(ctx, config.TLSConnect, config.TLSAccept, config.TLSPSKIdentity, []tlsPSKAttribute{{"tls_psk", config.TLSPSK}}, config.TLSIssuer, config.TLSSubject, &resp.Diagnostics)
+}
+
+// tlsPSKAttribute is an attribute holding the pre-shared key, e.g. tls_psk or tls_psk_wo.
+type tlsPSKAttribute struct {
+	name  string
+	value types.String
+}
+
+// validateTLS checks the tls_* attributes: connection types, and the PSK and certificate settings they need.
+// Exactly one of psks is set with psk connections.
+func validateTLS(ctx context.Context, connect types.String, accept types.Set, pskIdentity types.String, psks []tlsPSKAttribute, issuer, subject types.String, diags *diag.Diagnostics) {
+	if connect.IsUnknown() || accept.IsUnknown() {
+		return
+	}
+	used := []string{connect.ValueString()}
+	if connect.IsNull() {
+		used = []string{"no_encryption"}
+	}
+	if !accept.IsNull() {
+		accepted, _ := setToStrings(ctx, accept)
+		if len(accepted) == 0 {
+			diags.AddAttributeError(path.Root("tls_accept"), "Invalid attribute value", "`tls_accept` lists at least one connection type.")
+		}
+		for _, v := range accepted {
+			if !slices.Contains([]string{"no_encryption", "psk", "certificate"}, v) {
+				diags.AddAttributeError(path.Root("tls_accept"), "Invalid attribute value", fmt.Sprintf("%q is not one of `no_encryption`, `psk`, `certificate`.", v))
+			}
+		}
+		used = append(used, accepted...)
+	}
+
+	usesPSK := slices.Contains(used, "psk")
+	if usesPSK && pskIdentity.IsNull() {
+		diags.AddAttributeError(path.Root("tls_psk_identity"), "Missing attribute", "`psk` connections require `tls_psk_identity`.")
+	}
+	names := make([]string, 0, len(psks))
+	var set []tlsPSKAttribute
+	for _, psk := range psks {
+		names = append(names, "`"+psk.name+"`")
+		if !psk.value.IsNull() {
+			set = append(set, psk)
+		}
+	}
+	if usesPSK && len(set) == 0 {
+		diags.AddAttributeError(path.Root(psks[0].name), "Missing attribute", fmt.Sprintf("`psk` connections require %s.", strings.Join(names, " or ")))
+	}
+	if len(set) > 1 {
+		diags.AddAttributeError(path.Root(set[1].name), "Conflicting attributes", fmt.Sprintf("Only one of %s can be set.", strings.Join(names, " and ")))
+	}
+	if !usesPSK && !pskIdentity.IsNull() {
+		diags.AddAttributeError(path.Root("tls_psk_identity"), "Invalid attribute combination", "`tls_psk_identity` is only used by `psk` connections.")
+	}
+	for _, psk := range set {
+		if !usesPSK {
+			diags.AddAttributeError(path.Root(psk.name), "Invalid attribute combination", fmt.Sprintf("`%s` is only used by `psk` connections.", psk.name))
+		}
+		if !psk.value.IsUnknown() && !tlsPSKValue.MatchString(psk.value.ValueString()) {
+			diags.AddAttributeError(path.Root(psk.name), "Invalid attribute value", fmt.Sprintf("`%s` is 32 to 512 hex digits, e.g. the output of `openssl rand -hex 32`.", psk.name))
+		}
+	}
+	if !slices.Contains(used, "certificate") {
+		if !issuer.IsNull() {
+			diags.AddAttributeError(path.Root("tls_issuer"), "Invalid attribute combination", "`tls_issuer` is only used by `certificate` connections.")
+		}
+		if !subject.IsNull() {
+			diags.AddAttributeError(path.Root("tls_subject"), "Invalid attribute combination", "`tls_subject` is only used by `certificate` connections.")
+		}
+	}
+}
+
+func (r *proxyResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan proxyResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	zreq, d := expandProxy(ctx, plan)
+	resp.Diagnostics.Append(d...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	id, err := r.client.ProxyCreate(ctx, zreq)
+	if err != nil {
+		resp.Diagnostics.AddError("proxy.create error", err.Error())
+		return
+	}
+
+	plan.ID = types.StringValue(id)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *proxyResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state proxyResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	proxy, err := r.client.ProxyGetByID(ctx, state.ID.ValueString())
+	if err != nil {
+		if zabbix.IsNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError("proxy.get error", err.Error())
+		return
+	}
+
+	state.Name = types.StringValue(proxy.Name)
+	state.Mode = types.StringValue(codeToName(proxyModes, strconv.Itoa(proxy.OperatingMode), "active"))
+	state.Description = nullOrString(proxy.Description)
+	state.Address = types.StringNull()
+	if proxy.OperatingMode == zabbix.ProxyModePassive {
+		state.Address = types.StringValue(proxy.Address)
+		state.Port = types.StringValue(proxy.Port)
+	}
+	allowed := make([]string, 0)
+	for _, v := range strings.Split(proxy.AllowedAddresses, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			allowed = append(allowed, v)
+		}
+	}
+	if len(allowed) > 0 || !state.AllowedAddresses.IsNull() {
+		state.AllowedAddresses, _ = types.SetValueFrom(ctx, types.StringType, allowed)
+	}
+	state.ProxyGroupID = types.StringNull()
+	state.LocalAddress = types.StringNull()
+	if proxy.ProxyGroupID != "" && proxy.ProxyGroupID != "0" {
+		state.ProxyGroupID = types.StringValue(proxy.ProxyGroupID)
+		state.LocalAddress = types.StringValue(proxy.LocalAddress)
+		state.LocalPort = types.StringValue(proxy.LocalPort)
+	}
+	state.TLSConnect, state.TLSAccept = flattenTLS(ctx, proxy.TLSConnect, proxy.TLSAccept)
+	state.TLSIssuer = nullOrString(proxy.TLSIssuer)
+	state.TLSSubject = nullOrString(proxy.TLSSubject)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *proxyResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan proxyResourceModel
+	var state proxyResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	zreq, d := expandProxy(ctx, plan)
+	resp.Diagnostics.Append(d...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if err := r.client.ProxyUpdate(ctx, state.ID.ValueString(), zreq); err != nil {
+		resp.Diagnostics.AddError("proxy.update error", err.Error())
+		return
+	}
+
+	plan.ID = state.ID
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *proxyResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state proxyResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	err := r.client.ProxyDelete(ctx, state.ID.ValueString())
+	if err != nil && !zabbix.IsNotFound(err) {
+		resp.Diagnostics.AddError("proxy.delete error", err.Error())
+	}
+}
+
+func (r *proxyResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+func expandProxy(ctx context.Context, plan proxyResourceModel) (zabbix.ProxyCreateRequest, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	allowed, d := setToStringsOptional(ctx, plan.AllowedAddresses)
+	diags.Append(d...)
+	slices.Sort(allowed)
+	connect, accept, d := expandTLS(ctx, plan.TLSConnect, plan.TLSAccept)
+	diags.Append(d...)
+
+	return zabbix.ProxyCreateRequest{
+		Name:             plan.Name.ValueString(),
+		OperatingMode:    int(atoi64(nameToCode(proxyModes, plan.Mode.ValueString()))),
+		Description:      nullableString(plan.Description),
+		Address:          nullableString(plan.Address),
+		Port:             plan.Port.ValueString(),
+		AllowedAddresses: strings.Join(allowed, ","),
+		ProxyGroupID:     nullableString(plan.ProxyGroupID),
+		LocalAddress:     nullableString(plan.LocalAddress),
+		LocalPort:        plan.LocalPort.ValueString(),
+		TLSConnect:       connect,
+		TLSAccept:        accept,
+		TLSIssuer:        nullableString(plan.TLSIssuer),
+		TLSSubject:       nullableString(plan.TLSSubject),
+		TLSPSKIdentity:   nullableString(plan.TLSPSKIdentity),
+		TLSPSK:           nullableString(plan.TLSPSK),
+	}, diags
+}
+
+// expandTLS converts tls_connect to its code and tls_accept to its bitmask.
+func expandTLS(ctx context.Context, connect types.String, accept types.Set) (int, int, diag.Diagnostics) {
+	names, diags := setToStringsOptional(ctx, accept)
+	acceptBits := 0
+	for _, name := range names {
+		acceptBits |= int(atoi64(nameToCode(tlsConnectionTypes, name)))
+	}
+	if acceptBits == 0 {
+		acceptBits = zabbix.TLSNoEncryption
+	}
+	connectCode := int(atoi64(nameToCode(tlsConnectionTypes, connect.ValueString())))
+	if connectCode == 0 {
+		connectCode = zabbix.TLSNoEncryption
+	}
+	return connectCode, acceptBits, diags
+}
+
+// flattenTLS converts tls_connect and the tls_accept bitmask to their names.
+func flattenTLS(ctx context.Context, connect, accept int) (types.String, types.Set) {
+	var accepted []string
+	for _, bit := range []int{zabbix.TLSNoEncryption, zabbix.TLSPSK, zabbix.TLSCertificate} {
+		if accept&bit != 0 {
+			accepted = append(accepted, tlsConnectionTypes[bit])
+		}
+	}
+	if len(accepted) == 0 {
+		accepted = []string{"no_encryption"}
+	}
+	acceptSet, _ := types.SetValueFrom(ctx, types.StringType, accepted)
+	return types.StringValue(codeToName(tlsConnectionTypes, strconv.Itoa(connect), "no_encryption")), acceptSet
+}
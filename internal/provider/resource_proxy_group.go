@@ -0,0 +1,171 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/rushiii/terraform-provider-zabbix/internal/zabbix"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ resource.Resource                = &proxyGroupResource{}
+	_ resource.ResourceWithConfigure   = &proxyGroupResource{}
+	_ resource.ResourceWithImportState = &proxyGroupResource{}
+)
+
+type proxyGroupResource struct {
+	client *zabbix.Client
+}
+
+type proxyGroupResourceModel struct {
+	ID            types.String `tfsdk:"id"`
+	Name          types.String `tfsdk:"name"`
+	Description   types.String `tfsdk:"description"`
+	FailoverDelay types.String `tfsdk:"failover_delay"`
+	MinOnline     types.String `tfsdk:"min_online"`
+}
+
+func NewProxyGroupResource() resource.Resource {
+	return &proxyGroupResource{}
+}
+
+func (r *proxyGroupResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_proxy_group"
+}
+
+func (r *proxyGroupResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Zabbix proxy group resource (Zabbix 7.0+): proxies sharing the monitoring of their hosts, with failover.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				MarkdownDescription: "Internal Zabbix ID.",
+			},
+			"name": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Proxy group name.",
+			},
+			"description": schema.StringAttribute{
+				Optional: true,
+			},
+			"failover_delay": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString("1m"),
+				MarkdownDescription: "Time after which an unreachable proxy is considered offline and its hosts move to other proxies (10s-15m, suffixes and user macros supported). Default: `1m`.",
+			},
+			"min_online": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString("1"),
+				MarkdownDescription: "Minimum number of online proxies for the group to be online (1-1000, or a user macro). Default: `1`.",
+			},
+		},
+	}
+}
+
+func (r *proxyGroupResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	providerData, ok := req.ProviderData.(*providerData)
+	if !ok || providerData.Client == nil {
+		resp.Diagnostics.AddError("Invalid provider", "Zabbix client unavailable.")
+		return
+	}
+	r.client = providerData.Client
+}
+
+func (r *proxyGroupResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan proxyGroupResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	id, err := r.client.ProxyGroupCreate(ctx, expandProxyGroup(plan))
+	if err != nil {
+		resp.Diagnostics.AddError("proxygroup.create error", err.Error())
+		return
+	}
+
+	plan.ID = types.StringValue(id)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *proxyGroupResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state proxyGroupResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	group, err := r.client.ProxyGroupGetByID(ctx, state.ID.ValueString())
+	if err != nil {
+		if zabbix.IsNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError("proxygroup.get error", err.Error())
+		return
+	}
+
+	state.Name = types.StringValue(group.Name)
+	state.Description = nullOrString(group.Description)
+	state.FailoverDelay = types.StringValue(group.FailoverDelay)
+	state.MinOnline = types.StringValue(group.MinOnline)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *proxyGroupResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan proxyGroupResourceModel
+	var state proxyGroupResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.client.ProxyGroupUpdate(ctx, state.ID.ValueString(), expandProxyGroup(plan)); err != nil {
+		resp.Diagnostics.AddError("proxygroup.update error", err.Error())
+		return
+	}
+
+	plan.ID = state.ID
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *proxyGroupResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state proxyGroupResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	err := r.client.ProxyGroupDelete(ctx, state.ID.ValueString())
+	if err != nil && !zabbix.IsNotFound(err) {
+		resp.Diagnostics.AddError("proxygroup.delete error", err.Error())
+	}
+}
+
+func (r *proxyGroupResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+func expandProxyGroup(plan proxyGroupResourceModel) zabbix.ProxyGroup {
+	return zabbix.ProxyGroup{
+		Name:          plan.Name.ValueString(),
+		Description:   nullableString(plan.Description),
+		FailoverDelay: plan.FailoverDelay.ValueString(),
+		MinOnline:     plan.MinOnline.ValueString(),
+	}
+}
@@ -0,0 +1,175 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/rushiii/terraform-provider-zabbix/internal/zabbix"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ resource.Resource                   = &templateDashboardResource{}
+	_ resource.ResourceWithConfigure      = &templateDashboardResource{}
+	_ resource.ResourceWithImportState    = &templateDashboardResource{}
+	_ resource.ResourceWithValidateConfig = &templateDashboardResource{}
+)
+
+type templateDashboardResource struct {
+	client *zabbix.Client
+}
+
+type templateDashboardResourceModel struct {
+	dashboardModel
+	TemplateID types.String `tfsdk:"template_id"`
+}
+
+func NewTemplateDashboardResource() resource.Resource {
+	return &templateDashboardResource{}
+}
+
+func (r *templateDashboardResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_template_dashboard"
+}
+
+func (r *templateDashboardResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	attributes := dashboardAttributes()
+	attributes["template_id"] = schema.StringAttribute{
+		Required: true,
+		PlanModifiers: []planmodifier.String{
+			stringplanmodifier.RequiresReplace(),
+		},
+		MarkdownDescription: "ID of the template. Changing this forces recreation.",
+	}
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Zabbix template dashboard resource: a dashboard shown on every host linked to the template. Widgets use the items and graphs of the template.",
+		Attributes:          attributes,
+		Blocks: map[string]schema.Block{
+			"page": dashboardPageBlock(),
+		},
+	}
+}
+
+func (r *templateDashboardResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	providerData, ok := req.ProviderData.(*providerData)
+	if !ok || providerData.Client == nil {
+		resp.Diagnostics.AddError("Invalid provider", "Zabbix client unavailable.")
+		return
+	}
+	r.client = providerData.Client
+}
+
+func (r *templateDashboardResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config templateDashboardResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	validateDashboard(config.dashboardModel, &resp.Diagnostics)
+}
+
+func (r *templateDashboardResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan templateDashboardResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	pages, d := expandDashboardPages(plan.Pages)
+	resp.Diagnostics.Append(d...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	id, err := r.client.TemplateDashboardCreate(ctx, zabbix.TemplateDashboardCreateRequest{
+		TemplateID:    plan.TemplateID.ValueString(),
+		Name:          plan.Name.ValueString(),
+		DisplayPeriod: int(plan.DisplayPeriod.ValueInt64()),
+		AutoStart:     plan.AutoStart.ValueBool(),
+		Pages:         pages,
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("templatedashboard.create error", err.Error())
+		return
+	}
+
+	plan.ID = types.StringValue(id)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *templateDashboardResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state templateDashboardResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	dashboard, err := r.client.TemplateDashboardGetByID(ctx, state.ID.ValueString())
+	if err != nil {
+		if zabbix.IsNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError("templatedashboard.get error", err.Error())
+		return
+	}
+
+	state.TemplateID = types.StringValue(dashboard.TemplateID)
+	state.Name = types.StringValue(dashboard.Name)
+	state.DisplayPeriod = types.Int64Value(int64(dashboard.DisplayPeriod))
+	state.AutoStart = types.BoolValue(dashboard.AutoStart == "1")
+	state.Pages = flattenDashboardPages(dashboard.Pages, state.Pages)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *templateDashboardResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan templateDashboardResourceModel
+	var state templateDashboardResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	pages, d := expandDashboardPages(plan.Pages)
+	resp.Diagnostics.Append(d...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	err := r.client.TemplateDashboardUpdate(ctx, state.ID.ValueString(), zabbix.TemplateDashboardCreateRequest{
+		Name:          plan.Name.ValueString(),
+		DisplayPeriod: int(plan.DisplayPeriod.ValueInt64()),
+		AutoStart:     plan.AutoStart.ValueBool(),
+		Pages:         pages,
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("templatedashboard.update error", err.Error())
+		return
+	}
+
+	plan.ID = state.ID
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *templateDashboardResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state templateDashboardResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	err := r.client.TemplateDashboardDelete(ctx, state.ID.ValueString())
+	if err != nil && !zabbix.IsNotFound(err) {
+		resp.Diagnostics.AddError("templatedashboard.delete error", err.Error())
+	}
+}
+
+func (r *templateDashboardResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
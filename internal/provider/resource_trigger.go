@@ -2,9 +2,13 @@ package provider
 
 import (
 	"context"
+	"fmt"
+	"strconv"
+	"strings"
 
 	"github.com/rushiii/terraform-provider-zabbix/internal/zabbix"
 
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
@@ -12,13 +16,22 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
+// Trigger recovery and correlation modes, indexed by Zabbix code.
 var (
-	_ resource.Resource                = &triggerResource{}
-	_ resource.ResourceWithConfigure   = &triggerResource{}
-	_ resource.ResourceWithImportState = &triggerResource{}
+	triggerRecoveryModes    = []string{"expression", "recovery_expression", "none"}
+	triggerCorrelationModes = []string{"all", "tag"}
+)
+
+var (
+	_ resource.Resource                   = &triggerResource{}
+	_ resource.ResourceWithConfigure      = &triggerResource{}
+	_ resource.ResourceWithImportState    = &triggerResource{}
+	_ resource.ResourceWithValidateConfig = &triggerResource{}
+	_ resource.ResourceWithModifyPlan     = &triggerResource{}
 )
 
 type triggerResource struct {
@@ -26,11 +39,24 @@ type triggerResource struct {
 }
 
 type triggerResourceModel struct {
-	ID          types.String `tfsdk:"id"`
-	Description types.String `tfsdk:"description"`
-	Expression  types.String `tfsdk:"expression"`
-	Priority    types.String `tfsdk:"priority"`
-	Enabled     types.Bool   `tfsdk:"enabled"`
+	ID                 types.String `tfsdk:"id"`
+	Description        types.String `tfsdk:"description"`
+	Expression         types.String `tfsdk:"expression"`
+	Priority           types.String `tfsdk:"priority"`
+	Enabled            types.Bool   `tfsdk:"enabled"`
+	RecoveryMode       types.String `tfsdk:"recovery_mode"`
+	RecoveryExpression types.String `tfsdk:"recovery_expression"`
+	ManualClose        types.Bool   `tfsdk:"manual_close"`
+	MultipleEvents     types.Bool   `tfsdk:"multiple_events"`
+	EventName          types.String `tfsdk:"event_name"`
+	Opdata             types.String `tfsdk:"opdata"`
+	Comments           types.String `tfsdk:"comments"`
+	URL                types.String `tfsdk:"url"`
+	URLName            types.String `tfsdk:"url_name"`
+	Tags               types.Map    `tfsdk:"tags"`
+	CorrelationMode    types.String `tfsdk:"correlation_mode"`
+	CorrelationTag     types.String `tfsdk:"correlation_tag"`
+	Dependencies       types.Set    `tfsdk:"dependencies"`
 }
 
 func NewTriggerResource() resource.Resource {
@@ -41,34 +67,115 @@ func (r *triggerResource) Metadata(_ context.Context, req resource.MetadataReque
 	resp.TypeName = req.ProviderTypeName + "_trigger"
 }
 
-func (r *triggerResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
-	resp.Schema = schema.Schema{
-		MarkdownDescription: "Zabbix trigger resource.",
-		Attributes: map[string]schema.Attribute{
-			"id": schema.StringAttribute{
-				Computed: true,
-				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.UseStateForUnknown(),
-				},
+// triggerAttributes returns the schema attributes of a trigger.
+func triggerAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"id": schema.StringAttribute{
+			Computed: true,
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.UseStateForUnknown(),
 			},
-			"description": schema.StringAttribute{
-				Required: true,
+		},
+		"description": schema.StringAttribute{
+			Required:            true,
+			MarkdownDescription: "Trigger name.",
+		},
+		"expression": schema.StringAttribute{
+			Required:            true,
+			MarkdownDescription: "Problem expression, e.g. `last(/host/icmpping)=0`.",
+			Validators: []validator.String{
+				triggerExpression(),
 			},
-			"expression": schema.StringAttribute{
-				Required: true,
+		},
+		"priority": schema.StringAttribute{
+			Optional:            true,
+			Computed:            true,
+			Default:             stringdefault.StaticString("3"),
+			MarkdownDescription: "0..5 (0 not classified, 5 disaster).",
+		},
+		"enabled": schema.BoolAttribute{
+			Optional: true,
+			Computed: true,
+			Default:  booldefault.StaticBool(true),
+		},
+		"recovery_mode": schema.StringAttribute{
+			Optional:            true,
+			Computed:            true,
+			Default:             stringdefault.StaticString("expression"),
+			MarkdownDescription: "How problems are resolved: `expression` (problem expression is false), `recovery_expression` or `none` (manual close only).",
+			Validators: []validator.String{
+				stringOneOf(triggerRecoveryModes...),
 			},
-			"priority": schema.StringAttribute{
-				Optional: true,
-				Computed: true,
-				Default:  stringdefault.StaticString("3"),
-				MarkdownDescription: "0..5 (0 not classified, 5 disaster).",
+		},
+		"recovery_expression": schema.StringAttribute{
+			Optional:            true,
+			MarkdownDescription: "Recovery expression, with `recovery_mode = \"recovery_expression\"`.",
+			Validators: []validator.String{
+				triggerExpression(),
 			},
-			"enabled": schema.BoolAttribute{
-				Optional: true,
-				Computed: true,
-				Default:  booldefault.StaticBool(true),
+		},
+		"manual_close": schema.BoolAttribute{
+			Optional:            true,
+			Computed:            true,
+			Default:             booldefault.StaticBool(false),
+			MarkdownDescription: "Allow problems to be closed manually.",
+		},
+		"multiple_events": schema.BoolAttribute{
+			Optional:            true,
+			Computed:            true,
+			Default:             booldefault.StaticBool(false),
+			MarkdownDescription: "Generate a problem event on every evaluation to PROBLEM, not only on the first.",
+		},
+		"event_name": schema.StringAttribute{
+			Optional:            true,
+			MarkdownDescription: "Problem event name (defaults to the trigger name). Supports macros and expression macros.",
+		},
+		"opdata": schema.StringAttribute{
+			Optional:            true,
+			MarkdownDescription: "Operational data shown with problems, e.g. `Current: {ITEM.LASTVALUE1}`.",
+		},
+		"comments": schema.StringAttribute{
+			Optional:            true,
+			MarkdownDescription: "Trigger description (comments).",
+		},
+		"url": schema.StringAttribute{
+			Optional:            true,
+			MarkdownDescription: "URL associated with the trigger.",
+		},
+		"url_name": schema.StringAttribute{
+			Optional:            true,
+			MarkdownDescription: "Label of `url` (Zabbix 7.0+).",
+		},
+		"tags": schema.MapAttribute{
+			Optional:            true,
+			ElementType:         types.StringType,
+			MarkdownDescription: "Tags map (tag => value).",
+		},
+		"correlation_mode": schema.StringAttribute{
+			Optional:            true,
+			Computed:            true,
+			Default:             stringdefault.StaticString("all"),
+			MarkdownDescription: "Which problems an OK event closes: `all` or `tag` (problems with a matching `correlation_tag` value).",
+			Validators: []validator.String{
+				stringOneOf(triggerCorrelationModes...),
 			},
 		},
+		"correlation_tag": schema.StringAttribute{
+			Optional:            true,
+			MarkdownDescription: "Tag used for correlation, with `correlation_mode = \"tag\"`.",
+		},
+		"dependencies": schema.SetAttribute{
+			Optional:            true,
+			ElementType:         types.StringType,
+			MarkdownDescription: "IDs of triggers this trigger depends on: it is not evaluated while one of them is in PROBLEM state.",
+		},
+	}
+}
+
+func (r *triggerResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Zabbix trigger resource.",
+		Attributes:          triggerAttributes(),
 	}
 }
 
@@ -84,6 +191,97 @@ func (r *triggerResource) Configure(_ context.Context, req resource.ConfigureReq
 	r.client = providerData.Client
 }
 
+func (r *triggerResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config triggerResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	validateTrigger(config, &resp.Diagnostics)
+}
+
+// validateTrigger checks the recovery and correlation attribute combinations. Unknown values are skipped.
+func validateTrigger(config triggerResourceModel, diags *diag.Diagnostics) {
+	if !config.RecoveryMode.IsUnknown() && !config.RecoveryExpression.IsUnknown() {
+		recoveryExpression := config.RecoveryMode.ValueString() == "recovery_expression"
+		if recoveryExpression && config.RecoveryExpression.IsNull() {
+			diags.AddAttributeError(path.Root("recovery_expression"), "Missing attribute", "`recovery_mode = \"recovery_expression\"` requires `recovery_expression`.")
+		}
+		if !recoveryExpression && !config.RecoveryExpression.IsNull() {
+			diags.AddAttributeError(path.Root("recovery_expression"), "Invalid attribute combination", "`recovery_expression` requires `recovery_mode = \"recovery_expression\"`.")
+		}
+	}
+	if !config.CorrelationMode.IsUnknown() && !config.CorrelationTag.IsUnknown() {
+		byTag := config.CorrelationMode.ValueString() == "tag"
+		if byTag && config.CorrelationTag.IsNull() {
+			diags.AddAttributeError(path.Root("correlation_tag"), "Missing attribute", "`correlation_mode = \"tag\"` requires `correlation_tag`.")
+		}
+		if !byTag && !config.CorrelationTag.IsNull() {
+			diags.AddAttributeError(path.Root("correlation_tag"), "Invalid attribute combination", "`correlation_tag` requires `correlation_mode = \"tag\"`.")
+		}
+		if byTag && config.RecoveryMode.ValueString() == "none" {
+			diags.AddAttributeError(path.Root("correlation_mode"), "Invalid attribute combination", "Tag correlation is not available with `recovery_mode = \"none\"`.")
+		}
+	}
+}
+
+func (r *triggerResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() || r.client == nil {
+		return
+	}
+	var plan triggerResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	var state *triggerResourceModel
+	if !req.State.Raw.IsNull() {
+		state = &triggerResourceModel{}
+		resp.Diagnostics.Append(req.State.Get(ctx, state)...)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	checkTriggerExpressions(ctx, r.client, plan, state, &resp.Diagnostics)
+}
+
+// checkTriggerExpressions runs checkExpressionItems on the expressions of a trigger (or trigger prototype)
+// that are new or changed; state is nil on create.
+func checkTriggerExpressions(ctx context.Context, client *zabbix.Client, plan triggerResourceModel, state *triggerResourceModel, diags *diag.Diagnostics) {
+	if state == nil || !plan.Expression.Equal(state.Expression) {
+		checkExpressionItems(ctx, client, path.Root("expression"), plan.Expression, diags)
+	}
+	if state == nil || !plan.RecoveryExpression.Equal(state.RecoveryExpression) {
+		checkExpressionItems(ctx, client, path.Root("recovery_expression"), plan.RecoveryExpression, diags)
+	}
+}
+
+// checkExpressionItems warns about items of an expression that do not exist on their host or template.
+// Hosts given by macro, hosts not found (they may be created in the same apply) and keys with macros
+// are skipped; a missing item is only a warning since it may also be created in the same apply.
+func checkExpressionItems(ctx context.Context, client *zabbix.Client, attr path.Path, expr types.String, diags *diag.Diagnostics) {
+	if expr.IsNull() || expr.IsUnknown() {
+		return
+	}
+	parsed, err := zabbix.ParseExpression(expr.ValueString())
+	if err != nil {
+		return
+	}
+	checked := map[zabbix.ItemReference]bool{}
+	for _, ref := range parsed.References {
+		ref.Offset = 0
+		if checked[ref] || ref.Host == "" || ref.Host == "*" || strings.Contains(ref.Host, "{") || strings.Contains(ref.Key, "{") {
+			continue
+		}
+		checked[ref] = true
+		hostFound, itemFound, err := client.ItemKeyExists(ctx, ref.Host, ref.Key)
+		if err != nil {
+			diags.AddAttributeWarning(attr, "Could not check expression items", err.Error())
+			return
+		}
+		if hostFound && !itemFound {
+			diags.AddAttributeWarning(attr, "Unknown item", fmt.Sprintf("%q has no item with key %q (yet).", ref.Host, ref.Key))
+		}
+	}
+}
+
 func (r *triggerResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	var plan triggerResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
@@ -91,13 +289,12 @@ func (r *triggerResource) Create(ctx context.Context, req resource.CreateRequest
 		return
 	}
 
-	id, err := r.client.TriggerCreate(
-		ctx,
-		plan.Description.ValueString(),
-		plan.Expression.ValueString(),
-		plan.Priority.ValueString(),
-		plan.Enabled.ValueBool(),
-	)
+	triggerReq, d := expandTrigger(ctx, plan)
+	resp.Diagnostics.Append(d...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	id, err := r.client.TriggerCreate(ctx, triggerReq)
 	if err != nil {
 		resp.Diagnostics.AddError("trigger.create error", err.Error())
 		return
@@ -124,10 +321,7 @@ func (r *triggerResource) Read(ctx context.Context, req resource.ReadRequest, re
 		return
 	}
 
-	state.Description = types.StringValue(trigger.Description)
-	state.Expression = types.StringValue(trigger.Expression)
-	state.Priority = types.StringValue(trigger.Priority)
-	state.Enabled = types.BoolValue(zabbix.StatusToEnabled(trigger.Status))
+	resp.Diagnostics.Append(flattenTrigger(ctx, trigger, &state)...)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 }
 
@@ -140,14 +334,12 @@ func (r *triggerResource) Update(ctx context.Context, req resource.UpdateRequest
 		return
 	}
 
-	err := r.client.TriggerUpdate(
-		ctx,
-		state.ID.ValueString(),
-		plan.Description.ValueString(),
-		plan.Expression.ValueString(),
-		plan.Priority.ValueString(),
-		plan.Enabled.ValueBool(),
-	)
+	triggerReq, d := expandTrigger(ctx, plan)
+	resp.Diagnostics.Append(d...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	err := r.client.TriggerUpdate(ctx, state.ID.ValueString(), triggerReq)
 	if err != nil {
 		resp.Diagnostics.AddError("trigger.update error", err.Error())
 		return
@@ -172,3 +364,75 @@ func (r *triggerResource) Delete(ctx context.Context, req resource.DeleteRequest
 func (r *triggerResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
 	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
 }
+
+// expandTrigger builds the trigger.create/update request from the plan.
+func expandTrigger(ctx context.Context, plan triggerResourceModel) (zabbix.TriggerCreateRequest, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	tags, d := mapToTags(ctx, plan.Tags)
+	diags.Append(d...)
+	dependencies, d := setToStringsOptional(ctx, plan.Dependencies)
+	diags.Append(d...)
+	recoveryMode, _ := strconv.Atoi(nameToCode(triggerRecoveryModes, plan.RecoveryMode.ValueString()))
+	correlationMode, _ := strconv.Atoi(nameToCode(triggerCorrelationModes, plan.CorrelationMode.ValueString()))
+
+	return zabbix.TriggerCreateRequest{
+		Description:        plan.Description.ValueString(),
+		Expression:         plan.Expression.ValueString(),
+		Priority:           plan.Priority.ValueString(),
+		Enabled:            plan.Enabled.ValueBool(),
+		RecoveryMode:       recoveryMode,
+		RecoveryExpression: nullableString(plan.RecoveryExpression),
+		ManualClose:        plan.ManualClose.ValueBool(),
+		MultipleEvents:     plan.MultipleEvents.ValueBool(),
+		EventName:          nullableString(plan.EventName),
+		Opdata:             nullableString(plan.Opdata),
+		Comments:           nullableString(plan.Comments),
+		URL:                nullableString(plan.URL),
+		URLName:            nullableString(plan.URLName),
+		Tags:               tags,
+		CorrelationMode:    correlationMode,
+		CorrelationTag:     nullableString(plan.CorrelationTag),
+		DependencyIDs:      dependencies,
+	}, diags
+}
+
+// flattenTrigger copies a trigger read from Zabbix into the model.
+func flattenTrigger(ctx context.Context, trigger *zabbix.Trigger, state *triggerResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	state.Description = types.StringValue(trigger.Description)
+	// expandExpression output may differ from the configured expression by whitespace only.
+	if zabbix.NormalizeExpression(trigger.Expression) != zabbix.NormalizeExpression(state.Expression.ValueString()) {
+		state.Expression = types.StringValue(trigger.Expression)
+	}
+	state.Priority = types.StringValue(trigger.Priority)
+	state.Enabled = types.BoolValue(zabbix.StatusToEnabled(trigger.Status))
+	state.RecoveryMode = types.StringValue(codeToName(triggerRecoveryModes, trigger.RecoveryMode, "expression"))
+	if zabbix.NormalizeExpression(trigger.RecoveryExpression) != zabbix.NormalizeExpression(state.RecoveryExpression.ValueString()) {
+		state.RecoveryExpression = nullOrString(trigger.RecoveryExpression)
+	}
+	state.ManualClose = types.BoolValue(trigger.ManualClose == "1")
+	state.MultipleEvents = types.BoolValue(trigger.Type == "1")
+	state.EventName = nullOrString(trigger.EventName)
+	state.Opdata = nullOrString(trigger.Opdata)
+	state.Comments = nullOrString(trigger.Comments)
+	state.URL = nullOrString(trigger.URL)
+	state.URLName = nullOrString(trigger.URLName)
+	state.CorrelationMode = types.StringValue(codeToName(triggerCorrelationModes, trigger.CorrelationMode, "all"))
+	state.CorrelationTag = nullOrString(trigger.CorrelationTag)
+
+	if len(trigger.Tags) > 0 || !state.Tags.IsNull() {
+		tags, d := tagsToMap(ctx, trigger.Tags)
+		diags.Append(d...)
+		state.Tags = tags
+	}
+	dependencies := make([]string, 0, len(trigger.Dependencies))
+	for _, dep := range trigger.Dependencies {
+		dependencies = append(dependencies, dep.TriggerID)
+	}
+	if len(dependencies) > 0 || !state.Dependencies.IsNull() {
+		state.Dependencies, _ = types.SetValueFrom(ctx, types.StringType, dependencies)
+	}
+	return diags
+}
@@ -0,0 +1,194 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/rushiii/terraform-provider-zabbix/internal/zabbix"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ resource.Resource                   = &triggerPrototypeResource{}
+	_ resource.ResourceWithConfigure      = &triggerPrototypeResource{}
+	_ resource.ResourceWithImportState    = &triggerPrototypeResource{}
+	_ resource.ResourceWithValidateConfig = &triggerPrototypeResource{}
+	_ resource.ResourceWithModifyPlan     = &triggerPrototypeResource{}
+)
+
+type triggerPrototypeResource struct {
+	client *zabbix.Client
+}
+
+type triggerPrototypeResourceModel struct {
+	triggerResourceModel
+	RuleID   types.String `tfsdk:"rule_id"`
+	Discover types.Bool   `tfsdk:"discover"`
+}
+
+func NewTriggerPrototypeResource() resource.Resource {
+	return &triggerPrototypeResource{}
+}
+
+func (r *triggerPrototypeResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_trigger_prototype"
+}
+
+func (r *triggerPrototypeResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	attributes := prototypeAttributes(triggerAttributes())
+	attributes["description"] = schema.StringAttribute{
+		Required:            true,
+		MarkdownDescription: "Trigger prototype name, usually with LLD macros (e.g. `High bandwidth on {#IFNAME}`).",
+	}
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Zabbix trigger prototype resource: triggers created by a discovery rule for each discovered entity. The expression references item prototypes of the rule, e.g. `last(/tpl/net.if.in[{#IFNAME}])>100M`.",
+		Attributes:          attributes,
+	}
+}
+
+func (r *triggerPrototypeResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	providerData, ok := req.ProviderData.(*providerData)
+	if !ok || providerData.Client == nil {
+		resp.Diagnostics.AddError("Invalid provider", "Zabbix client unavailable.")
+		return
+	}
+	r.client = providerData.Client
+}
+
+func (r *triggerPrototypeResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config triggerPrototypeResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	validateTrigger(config.triggerResourceModel, &resp.Diagnostics)
+}
+
+func (r *triggerPrototypeResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() || r.client == nil {
+		return
+	}
+	var plan triggerPrototypeResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	var state *triggerResourceModel
+	if !req.State.Raw.IsNull() {
+		var prototype triggerPrototypeResourceModel
+		resp.Diagnostics.Append(req.State.Get(ctx, &prototype)...)
+		state = &prototype.triggerResourceModel
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	checkTriggerExpressions(ctx, r.client, plan.triggerResourceModel, state, &resp.Diagnostics)
+}
+
+func (r *triggerPrototypeResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan triggerPrototypeResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	triggerReq, d := expandTrigger(ctx, plan.triggerResourceModel)
+	resp.Diagnostics.Append(d...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	id, err := r.client.TriggerPrototypeCreate(ctx, zabbix.TriggerPrototypeCreateRequest{
+		TriggerCreateRequest: triggerReq,
+		Discover:             plan.Discover.ValueBool(),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("triggerprototype.create error", err.Error())
+		return
+	}
+
+	// The rule follows from the item prototypes of the expression: check it is the configured one.
+	prototype, err := r.client.TriggerPrototypeGetByID(ctx, id)
+	if err != nil {
+		resp.Diagnostics.AddError("triggerprototype.get error", err.Error())
+		return
+	}
+	if prototype.DiscoveryRule.ItemID != plan.RuleID.ValueString() {
+		_ = r.client.TriggerPrototypeDelete(ctx, id)
+		resp.Diagnostics.AddAttributeError(path.Root("rule_id"), "Discovery rule mismatch",
+			fmt.Sprintf("The expression references item prototypes of discovery rule %s, not %s.", prototype.DiscoveryRule.ItemID, plan.RuleID.ValueString()))
+		return
+	}
+
+	plan.ID = types.StringValue(id)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *triggerPrototypeResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state triggerPrototypeResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	prototype, err := r.client.TriggerPrototypeGetByID(ctx, state.ID.ValueString())
+	if err != nil {
+		if zabbix.IsNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError("triggerprototype.get error", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(flattenTrigger(ctx, &prototype.Trigger, &state.triggerResourceModel)...)
+	state.RuleID = types.StringValue(prototype.DiscoveryRule.ItemID)
+	state.Discover = types.BoolValue(zabbix.StatusToEnabled(prototype.Discover))
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *triggerPrototypeResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan triggerPrototypeResourceModel
+	var state triggerPrototypeResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	triggerReq, d := expandTrigger(ctx, plan.triggerResourceModel)
+	resp.Diagnostics.Append(d...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	err := r.client.TriggerPrototypeUpdate(ctx, state.ID.ValueString(), zabbix.TriggerPrototypeCreateRequest{
+		TriggerCreateRequest: triggerReq,
+		Discover:             plan.Discover.ValueBool(),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("triggerprototype.update error", err.Error())
+		return
+	}
+
+	plan.ID = state.ID
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *triggerPrototypeResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state triggerPrototypeResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	err := r.client.TriggerPrototypeDelete(ctx, state.ID.ValueString())
+	if err != nil && !zabbix.IsNotFound(err) {
+		resp.Diagnostics.AddError("triggerprototype.delete error", err.Error())
+	}
+}
+
+func (r *triggerPrototypeResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
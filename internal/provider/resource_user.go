@@ -2,12 +2,15 @@ package provider
 
 import (
 	"context"
+	"strconv"
 
 	"github.com/rushiii/terraform-provider-zabbix/internal/zabbix"
 
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
@@ -15,9 +18,10 @@ import (
 )
 
 var (
-	_ resource.Resource                = &userResource{}
-	_ resource.ResourceWithConfigure   = &userResource{}
-	_ resource.ResourceWithImportState = &userResource{}
+	_ resource.Resource                   = &userResource{}
+	_ resource.ResourceWithConfigure      = &userResource{}
+	_ resource.ResourceWithImportState    = &userResource{}
+	_ resource.ResourceWithValidateConfig = &userResource{}
 )
 
 type userResource struct {
@@ -25,15 +29,41 @@ type userResource struct {
 }
 
 type userResourceModel struct {
-	ID            types.String `tfsdk:"id"`
-	Username      types.String `tfsdk:"username"`
-	Name          types.String `tfsdk:"name"`
-	Password      types.String `tfsdk:"password"`
-	UserGroupIDs  types.Set    `tfsdk:"user_group_ids"`
-	RoleID        types.String `tfsdk:"role_id"`
-	Email         types.String `tfsdk:"email"`
+	ID                types.String     `tfsdk:"id"`
+	Username          types.String     `tfsdk:"username"`
+	Name              types.String     `tfsdk:"name"`
+	Password          types.String     `tfsdk:"password"`
+	PasswordWO        types.String     `tfsdk:"password_wo"`
+	PasswordWOVersion types.Int64      `tfsdk:"password_wo_version"`
+	UserGroupIDs      types.Set        `tfsdk:"user_group_ids"`
+	RoleID            types.String     `tfsdk:"role_id"`
+	Email             types.String     `tfsdk:"email"`
+	Media             []userMediaModel `tfsdk:"media"`
+	Surname           types.String     `tfsdk:"surname"`
+	Lang              types.String     `tfsdk:"lang"`
+	Timezone          types.String     `tfsdk:"timezone"`
+	Theme             types.String     `tfsdk:"theme"`
+	Autologin         types.Bool       `tfsdk:"autologin"`
+	Autologout        types.String     `tfsdk:"autologout"`
+	Refresh           types.String     `tfsdk:"refresh"`
+	RowsPerPage       types.Int64      `tfsdk:"rows_per_page"`
 }
 
+type userMediaModel struct {
+	MediaTypeID types.String `tfsdk:"media_type_id"`
+	SendTo      types.String `tfsdk:"send_to"`
+	Severity    types.Int64  `tfsdk:"severity"`
+	Period      types.String `tfsdk:"period"`
+	Enabled     types.Bool   `tfsdk:"enabled"`
+}
+
+// Defaults of the Email media added through the `email` shorthand.
+const (
+	userEmailMediaTypeID = "1"
+	userMediaAllSeverity = 63
+	userMediaAlwaysOn    = "1-7,00:00-24:00"
+)
+
 func NewUserResource() resource.Resource {
 	return &userResource{}
 }
@@ -63,7 +93,63 @@ func (r *userResource) Schema(_ context.Context, _ resource.SchemaRequest, resp
 			"password": schema.StringAttribute{
 				Optional:            true,
 				Sensitive:           true,
-				MarkdownDescription: "User password. Omit or leave empty to create a user without password (e.g. notification-only); on update, leave unchanged by not setting this attribute.",
+				MarkdownDescription: "User password. Omit or leave empty to create a user without password (e.g. notification-only); on update, leave unchanged by not setting this attribute. Stored in state; prefer `password_wo` on Terraform 1.11+.",
+			},
+			"password_wo": schema.StringAttribute{
+				Optional:            true,
+				Sensitive:           true,
+				WriteOnly:           true,
+				MarkdownDescription: "Write-only user password (Terraform 1.11+), never stored in state. Sent on create, and on update only when `password_wo_version` changes. Conflicts with `password`.",
+			},
+			"password_wo_version": schema.Int64Attribute{
+				Optional:            true,
+				MarkdownDescription: "Change this value (e.g. increment it) to send `password_wo` again and rotate the password.",
+			},
+			"surname": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Surname.",
+			},
+			"lang": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString("default"),
+				MarkdownDescription: "Frontend language code (e.g. `en_US`, `fr_FR`) or `default` for the system default.",
+			},
+			"timezone": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString("default"),
+				MarkdownDescription: "Time zone (e.g. `Europe/Paris`) or `default` for the system default.",
+			},
+			"theme": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString("default"),
+				MarkdownDescription: "Frontend theme: `default`, `blue-theme`, `dark-theme`, `hc-light`, `hc-dark`.",
+			},
+			"autologin": schema.BoolAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+				MarkdownDescription: "Remember the user's frontend session (auto-login). Requires `autologout = \"0\"`.",
+			},
+			"autologout": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString("15m"),
+				MarkdownDescription: "Session inactivity timeout (e.g. `15m`, `1h`); `0` disables auto-logout and is required with `autologin`.",
+			},
+			"refresh": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString("30s"),
+				MarkdownDescription: "Frontend refresh interval (e.g. `30s`, `1m`).",
+			},
+			"rows_per_page": schema.Int64Attribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             int64default.StaticInt64(50),
+				MarkdownDescription: "Rows shown per page in frontend lists.",
 			},
 			"user_group_ids": schema.SetAttribute{
 				Required:            true,
@@ -74,11 +160,46 @@ func (r *userResource) Schema(_ context.Context, _ resource.SchemaRequest, resp
 				Optional:            true,
 				Computed:            true,
 				Default:             stringdefault.StaticString("1"),
-				MarkdownDescription: "Role: 1=User, 2=Admin, 3=Super admin.",
+				MarkdownDescription: "Role ID. Built-in roles: 1=User role, 2=Admin role, 3=Super admin role; use `zabbix_user_role` (resource or data source) for custom roles.",
 			},
 			"email": schema.StringAttribute{
 				Optional:            true,
-				MarkdownDescription: "Email address for notifications (adds Email media to the user).",
+				MarkdownDescription: "Email address for notifications (adds Email media to the user). Shorthand for a `media` block with media type 1, all severities, 24/7.",
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"media": schema.ListNestedBlock{
+				MarkdownDescription: "Notification media (email, webhook, SMS, ...), in the order Zabbix returns them (creation order).",
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"media_type_id": schema.StringAttribute{
+							Required:            true,
+							MarkdownDescription: "Media type ID (see `zabbix_media_type`).",
+						},
+						"send_to": schema.StringAttribute{
+							Required:            true,
+							MarkdownDescription: "Recipient: email address, Slack/PagerDuty user or key, phone number...",
+						},
+						"severity": schema.Int64Attribute{
+							Optional:            true,
+							Computed:            true,
+							Default:             int64default.StaticInt64(userMediaAllSeverity),
+							MarkdownDescription: "Severities to notify, as a bitmask: 1=Not classified, 2=Information, 4=Warning, 8=Average, 16=High, 32=Disaster (63 = all).",
+						},
+						"period": schema.StringAttribute{
+							Optional:            true,
+							Computed:            true,
+							Default:             stringdefault.StaticString(userMediaAlwaysOn),
+							MarkdownDescription: "When the media is active, e.g. `1-5,09:00-18:00;6-7,10:00-14:00`.",
+						},
+						"enabled": schema.BoolAttribute{
+							Optional:            true,
+							Computed:            true,
+							Default:             booldefault.StaticBool(true),
+							MarkdownDescription: "Whether the media is active.",
+						},
+					},
+				},
 			},
 		},
 	}
@@ -107,14 +228,18 @@ func (r *userResource) Create(ctx context.Context, req resource.CreateRequest, r
 	if resp.Diagnostics.HasError() {
 		return
 	}
-	id, err := r.client.UserCreate(ctx, zabbix.UserCreateRequest{
-		Username:   plan.Username.ValueString(),
-		Name:       plan.Name.ValueString(),
-		Password:   plan.Password.ValueString(),
-		UserGrpIDs: groupIDs,
-		RoleID:     plan.RoleID.ValueString(),
-		Email:      plan.Email.ValueString(),
-	})
+	// Write-only values are only available in the configuration, never in the plan.
+	var passwordWO types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("password_wo"), &passwordWO)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	zreq := expandUser(plan, groupIDs)
+	zreq.Password = plan.Password.ValueString()
+	if !passwordWO.IsNull() {
+		zreq.Password = passwordWO.ValueString()
+	}
+	id, err := r.client.UserCreate(ctx, zreq)
 	if err != nil {
 		resp.Diagnostics.AddError("user.create error", err.Error())
 		return
@@ -140,22 +265,26 @@ func (r *userResource) Read(ctx context.Context, req resource.ReadRequest, resp
 	}
 	state.Username = types.StringValue(u.Username)
 	state.Name = types.StringValue(u.Name)
+	state.Surname = nullOrString(u.Surname)
+	state.Lang = types.StringValue(u.Lang)
+	state.Timezone = types.StringValue(u.Timezone)
+	state.Theme = types.StringValue(u.Theme)
+	state.Autologin = types.BoolValue(u.Autologin == "1")
+	state.Autologout = types.StringValue(u.Autologout)
+	state.Refresh = types.StringValue(u.Refresh)
+	if rows, err := strconv.ParseInt(u.RowsPerPage, 10, 64); err == nil {
+		state.RowsPerPage = types.Int64Value(rows)
+	}
 	if u.RoleID != "" {
 		state.RoleID = types.StringValue(u.RoleID)
 	}
-	state.Email = types.StringNull()
-	for _, m := range u.Medias {
-		if m.MediaTypeID == "1" {
-			state.Email = types.StringValue(string(m.SendTo))
-			break
-		}
-	}
+	state.Email, state.Media = flattenUserMedias(u.Medias, !state.Email.IsNull())
 	groupIDs := make([]string, 0, len(u.Usrgrps))
 	for _, g := range u.Usrgrps {
 		groupIDs = append(groupIDs, g.UsrgrpID)
 	}
 	state.UserGroupIDs, _ = types.SetValueFrom(ctx, types.StringType, groupIDs)
-	// password is not returned by API; keep from state
+	// password is not returned by API; keep from state (password_wo is never stored)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 }
 
@@ -172,16 +301,20 @@ func (r *userResource) Update(ctx context.Context, req resource.UpdateRequest, r
 	if resp.Diagnostics.HasError() {
 		return
 	}
-	reqUpdate := zabbix.UserCreateRequest{
-		Username:   plan.Username.ValueString(),
-		Name:       plan.Name.ValueString(),
-		UserGrpIDs: groupIDs,
-		RoleID:     plan.RoleID.ValueString(),
-		Email:      plan.Email.ValueString(),
-	}
+	reqUpdate := expandUser(plan, groupIDs)
 	if !plan.Password.IsNull() && plan.Password.ValueString() != "" {
 		reqUpdate.Password = plan.Password.ValueString()
 	}
+	if !plan.PasswordWOVersion.Equal(state.PasswordWOVersion) {
+		var passwordWO types.String
+		resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("password_wo"), &passwordWO)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		if !passwordWO.IsNull() && passwordWO.ValueString() != "" {
+			reqUpdate.Password = passwordWO.ValueString()
+		}
+	}
 	if err := r.client.UserUpdate(ctx, state.ID.ValueString(), reqUpdate); err != nil {
 		resp.Diagnostics.AddError("user.update error", err.Error())
 		return
@@ -205,3 +338,100 @@ func (r *userResource) Delete(ctx context.Context, req resource.DeleteRequest, r
 func (r *userResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
 	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
 }
+
+func (r *userResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var password, passwordWO types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("password"), &password)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("password_wo"), &passwordWO)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if !password.IsNull() && !passwordWO.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("password_wo"),
+			"Conflicting attributes",
+			"Only one of `password` and `password_wo` can be set.",
+		)
+	}
+
+	// Zabbix rejects auto-login with auto-logout, including the default `15m`.
+	var autologin types.Bool
+	var autologout types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("autologin"), &autologin)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("autologout"), &autologout)...)
+	if resp.Diagnostics.HasError() || !autologin.ValueBool() || autologout.IsUnknown() {
+		return
+	}
+	if autologout.ValueString() != "0" {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("autologout"),
+			"Invalid attribute combination",
+			"`autologout` must be set to \"0\" when `autologin` is true.",
+		)
+	}
+}
+
+// expandUser builds the user.create/update request from the plan, without password.
+func expandUser(plan userResourceModel, groupIDs []string) zabbix.UserCreateRequest {
+	return zabbix.UserCreateRequest{
+		Username:    plan.Username.ValueString(),
+		Name:        plan.Name.ValueString(),
+		UserGrpIDs:  groupIDs,
+		RoleID:      plan.RoleID.ValueString(),
+		Medias:      expandUserMedias(plan),
+		Surname:     nullableString(plan.Surname),
+		Lang:        plan.Lang.ValueString(),
+		Timezone:    plan.Timezone.ValueString(),
+		Theme:       plan.Theme.ValueString(),
+		Autologin:   zabbix.BoolToInt(plan.Autologin.ValueBool()),
+		Autologout:  plan.Autologout.ValueString(),
+		Refresh:     plan.Refresh.ValueString(),
+		RowsPerPage: int(plan.RowsPerPage.ValueInt64()),
+	}
+}
+
+// expandUserMedias returns the `email` shorthand (if set) followed by the `media` blocks.
+func expandUserMedias(plan userResourceModel) []zabbix.UserMedia {
+	out := make([]zabbix.UserMedia, 0, len(plan.Media)+1)
+	if email := nullableString(plan.Email); email != "" {
+		out = append(out, zabbix.UserMedia{
+			MediaTypeID: userEmailMediaTypeID,
+			SendTo:      email,
+			Active:      "1", // kept disabled, as the shorthand always created it; use a media block to enable it
+			Severity:    strconv.Itoa(userMediaAllSeverity),
+			Period:      userMediaAlwaysOn,
+		})
+	}
+	for _, m := range plan.Media {
+		out = append(out, zabbix.UserMedia{
+			MediaTypeID: m.MediaTypeID.ValueString(),
+			SendTo:      m.SendTo.ValueString(),
+			Active:      strconv.Itoa(zabbix.BoolToStatus(m.Enabled.ValueBool())),
+			Severity:    strconv.FormatInt(m.Severity.ValueInt64(), 10),
+			Period:      m.Period.ValueString(),
+		})
+	}
+	return out
+}
+
+// flattenUserMedias splits the user's media into the `email` shorthand and `media` blocks. When the
+// shorthand is in use, the first Email media (type 1) is reported as `email`; everything else is a block.
+func flattenUserMedias(medias []zabbix.UserMedia, useEmail bool) (types.String, []userMediaModel) {
+	email := types.StringNull()
+	out := make([]userMediaModel, 0, len(medias))
+	for _, m := range medias {
+		if useEmail && email.IsNull() && m.MediaTypeID == userEmailMediaTypeID {
+			email = types.StringValue(m.SendTo)
+			continue
+		}
+		severity, _ := strconv.ParseInt(m.Severity, 10, 64)
+		out = append(out, userMediaModel{
+			MediaTypeID: types.StringValue(m.MediaTypeID),
+			SendTo:      types.StringValue(m.SendTo),
+			Severity:    types.Int64Value(severity),
+			Period:      types.StringValue(m.Period),
+			Enabled:     types.BoolValue(zabbix.StatusToEnabled(m.Active)),
+		})
+	}
+	return email, out
+}
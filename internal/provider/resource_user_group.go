@@ -8,8 +8,11 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
@@ -28,6 +31,12 @@ func setToStringsOptionalUserGroup(ctx context.Context, value types.Set) ([]stri
 	return out, nil
 }
 
+// Permission names exposed in the schema, indexed by Zabbix code (1 is unused).
+var userGroupPermissions = []string{"deny", "", "read", "read-write"}
+
+// gui_access names, indexed by Zabbix code.
+var userGroupGuiAccess = []string{"default", "internal", "ldap", "disabled"}
+
 var (
 	_ resource.Resource                = &userGroupResource{}
 	_ resource.ResourceWithConfigure   = &userGroupResource{}
@@ -39,9 +48,26 @@ type userGroupResource struct {
 }
 
 type userGroupResourceModel struct {
-	ID                 types.String `tfsdk:"id"`
-	Name               types.String `tfsdk:"name"`
-	HostGroupReadIDs   types.Set    `tfsdk:"host_group_read_ids"`
+	ID                  types.String              `tfsdk:"id"`
+	Name                types.String              `tfsdk:"name"`
+	HostGroupReadIDs    types.Set                 `tfsdk:"host_group_read_ids"`
+	GuiAccess           types.String              `tfsdk:"gui_access"`
+	UsersStatus         types.String              `tfsdk:"users_status"`
+	DebugMode           types.Bool                `tfsdk:"debug_mode"`
+	HostGroupRights     []userGroupRightModel     `tfsdk:"host_group_rights"`
+	TemplateGroupRights []userGroupRightModel     `tfsdk:"template_group_rights"`
+	TagFilters          []userGroupTagFilterModel `tfsdk:"tag_filters"`
+}
+
+type userGroupRightModel struct {
+	ID         types.String `tfsdk:"id"`
+	Permission types.String `tfsdk:"permission"`
+}
+
+type userGroupTagFilterModel struct {
+	HostGroupID types.String `tfsdk:"host_group_id"`
+	Tag         types.String `tfsdk:"tag"`
+	Value       types.String `tfsdk:"value"`
 }
 
 func NewUserGroupResource() resource.Resource {
@@ -52,6 +78,27 @@ func (r *userGroupResource) Metadata(_ context.Context, req resource.MetadataReq
 	resp.TypeName = req.ProviderTypeName + "_user_group"
 }
 
+func userGroupRightBlock(description, idDescription string) schema.SetNestedBlock {
+	return schema.SetNestedBlock{
+		MarkdownDescription: description,
+		NestedObject: schema.NestedBlockObject{
+			Attributes: map[string]schema.Attribute{
+				"id": schema.StringAttribute{
+					Required:            true,
+					MarkdownDescription: idDescription,
+				},
+				"permission": schema.StringAttribute{
+					Required:            true,
+					MarkdownDescription: "Access level: `deny`, `read` or `read-write`.",
+					Validators: []validator.String{
+						stringOneOf("deny", "read", "read-write"),
+					},
+				},
+			},
+		},
+	}
+}
+
 func (r *userGroupResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		MarkdownDescription: "Zabbix user group (e.g. for notification recipients).",
@@ -69,7 +116,64 @@ func (r *userGroupResource) Schema(_ context.Context, _ resource.SchemaRequest,
 			"host_group_read_ids": schema.SetAttribute{
 				Optional:            true,
 				ElementType:         types.StringType,
-				MarkdownDescription: "IDs of host groups to grant Read permission (\"Autorisation de l'hôte\" in Zabbix UI).",
+				MarkdownDescription: "IDs of host groups to grant Read permission (\"Autorisation de l'hôte\" in Zabbix UI). Shorthand for `host_group_rights` blocks with `permission = \"read\"`.",
+			},
+			"gui_access": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString("default"),
+				MarkdownDescription: "Frontend authentication method: `default`, `internal`, `ldap` or `disabled`.",
+				Validators: []validator.String{
+					stringOneOf(userGroupGuiAccess...),
+				},
+			},
+			"users_status": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString("enabled"),
+				MarkdownDescription: "Whether users of the group are `enabled` or `disabled`.",
+				Validators: []validator.String{
+					stringOneOf("enabled", "disabled"),
+				},
+			},
+			"debug_mode": schema.BoolAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+				MarkdownDescription: "Enable frontend debug mode for members of the group.",
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"host_group_rights": userGroupRightBlock(
+				"Permission on a host group.",
+				"Host group ID.",
+			),
+			"template_group_rights": userGroupRightBlock(
+				"Permission on a template group. Zabbix 6.2+.",
+				"Template group ID.",
+			),
+			"tag_filters": schema.SetNestedBlock{
+				MarkdownDescription: "Only show problems of the host group that carry this tag (and value).",
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"host_group_id": schema.StringAttribute{
+							Required:            true,
+							MarkdownDescription: "Host group ID.",
+						},
+						"tag": schema.StringAttribute{
+							Optional:            true,
+							Computed:            true,
+							Default:             stringdefault.StaticString(""),
+							MarkdownDescription: "Tag name. Empty means all tags.",
+						},
+						"value": schema.StringAttribute{
+							Optional:            true,
+							Computed:            true,
+							Default:             stringdefault.StaticString(""),
+							MarkdownDescription: "Tag value. Empty means any value.",
+						},
+					},
+				},
 			},
 		},
 	}
@@ -93,8 +197,7 @@ func (r *userGroupResource) Create(ctx context.Context, req resource.CreateReque
 	if resp.Diagnostics.HasError() {
 		return
 	}
-	readIDs, _ := setToStringsOptionalUserGroup(ctx, plan.HostGroupReadIDs)
-	id, err := r.client.UserGroupCreate(ctx, plan.Name.ValueString(), readIDs)
+	id, err := r.client.UserGroupCreate(ctx, expandUserGroup(ctx, plan))
 	if err != nil {
 		resp.Diagnostics.AddError("usergroup.create error", err.Error())
 		return
@@ -119,6 +222,43 @@ func (r *userGroupResource) Read(ctx context.Context, req resource.ReadRequest,
 		return
 	}
 	state.Name = types.StringValue(grp.Name)
+	state.GuiAccess = types.StringValue(userGroupGuiAccessName(grp.GuiAccess))
+	if grp.UsersStatus == "1" {
+		state.UsersStatus = types.StringValue("disabled")
+	} else {
+		state.UsersStatus = types.StringValue("enabled")
+	}
+	state.DebugMode = types.BoolValue(grp.DebugMode == "1")
+
+	// Read rights on groups listed in host_group_read_ids stay there; everything else goes to host_group_rights.
+	readIDs, _ := setToStringsOptionalUserGroup(ctx, state.HostGroupReadIDs)
+	shorthand := make(map[string]struct{}, len(readIDs))
+	for _, id := range readIDs {
+		shorthand[id] = struct{}{}
+	}
+	keptReadIDs := make([]string, 0, len(readIDs))
+	hostRights := make([]zabbix.UserGroupRight, 0, len(grp.HostGroupRights))
+	for _, right := range grp.HostGroupRights {
+		if _, ok := shorthand[right.ID]; ok && right.Permission == zabbix.UsergroupPermissionRead {
+			keptReadIDs = append(keptReadIDs, right.ID)
+			continue
+		}
+		hostRights = append(hostRights, right)
+	}
+	if !state.HostGroupReadIDs.IsNull() {
+		state.HostGroupReadIDs, _ = types.SetValueFrom(ctx, types.StringType, keptReadIDs)
+	}
+	state.HostGroupRights = flattenUserGroupRights(hostRights)
+	state.TemplateGroupRights = flattenUserGroupRights(grp.TemplateGroupRights)
+
+	state.TagFilters = make([]userGroupTagFilterModel, 0, len(grp.TagFilters))
+	for _, f := range grp.TagFilters {
+		state.TagFilters = append(state.TagFilters, userGroupTagFilterModel{
+			HostGroupID: types.StringValue(f.GroupID),
+			Tag:         types.StringValue(f.Tag),
+			Value:       types.StringValue(f.Value),
+		})
+	}
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 }
 
@@ -130,14 +270,7 @@ func (r *userGroupResource) Update(ctx context.Context, req resource.UpdateReque
 	if resp.Diagnostics.HasError() {
 		return
 	}
-	var readIDs []string
-	if !plan.HostGroupReadIDs.IsNull() {
-		readIDs, _ = setToStringsOptionalUserGroup(ctx, plan.HostGroupReadIDs)
-		if readIDs == nil {
-			readIDs = []string{}
-		}
-	}
-	if err := r.client.UserGroupUpdate(ctx, state.ID.ValueString(), plan.Name.ValueString(), readIDs); err != nil {
+	if err := r.client.UserGroupUpdate(ctx, state.ID.ValueString(), expandUserGroup(ctx, plan)); err != nil {
 		resp.Diagnostics.AddError("usergroup.update error", err.Error())
 		return
 	}
@@ -160,3 +293,58 @@ func (r *userGroupResource) Delete(ctx context.Context, req resource.DeleteReque
 func (r *userGroupResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
 	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
 }
+
+func expandUserGroup(ctx context.Context, plan userGroupResourceModel) zabbix.UserGroupCreateRequest {
+	hostRights := expandUserGroupRights(plan.HostGroupRights)
+	readIDs, _ := setToStringsOptionalUserGroup(ctx, plan.HostGroupReadIDs)
+	for _, gid := range readIDs {
+		if gid != "" {
+			hostRights = append(hostRights, zabbix.UserGroupRight{ID: gid, Permission: zabbix.UsergroupPermissionRead})
+		}
+	}
+
+	tagFilters := make([]zabbix.UserGroupTagFilter, 0, len(plan.TagFilters))
+	for _, f := range plan.TagFilters {
+		tagFilters = append(tagFilters, zabbix.UserGroupTagFilter{
+			GroupID: f.HostGroupID.ValueString(),
+			Tag:     f.Tag.ValueString(),
+			Value:   f.Value.ValueString(),
+		})
+	}
+
+	return zabbix.UserGroupCreateRequest{
+		Name:                plan.Name.ValueString(),
+		HostGroupRights:     hostRights,
+		TemplateGroupRights: expandUserGroupRights(plan.TemplateGroupRights),
+		TagFilters:          tagFilters,
+		GuiAccess:           int(atoi64(nameToCode(userGroupGuiAccess, plan.GuiAccess.ValueString()))),
+		UsersStatus:         zabbix.BoolToInt(plan.UsersStatus.ValueString() == "disabled"),
+		DebugMode:           zabbix.BoolToInt(plan.DebugMode.ValueBool()),
+	}
+}
+
+func expandUserGroupRights(in []userGroupRightModel) []zabbix.UserGroupRight {
+	out := make([]zabbix.UserGroupRight, 0, len(in))
+	for _, r := range in {
+		out = append(out, zabbix.UserGroupRight{
+			ID:         r.ID.ValueString(),
+			Permission: nameToCode(userGroupPermissions, r.Permission.ValueString()),
+		})
+	}
+	return out
+}
+
+func flattenUserGroupRights(in []zabbix.UserGroupRight) []userGroupRightModel {
+	out := make([]userGroupRightModel, 0, len(in))
+	for _, r := range in {
+		out = append(out, userGroupRightModel{
+			ID:         types.StringValue(r.ID),
+			Permission: types.StringValue(codeToName(userGroupPermissions, r.Permission, "deny")),
+		})
+	}
+	return out
+}
+
+func userGroupGuiAccessName(code string) string {
+	return codeToName(userGroupGuiAccess, code, "default")
+}
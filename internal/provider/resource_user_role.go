@@ -0,0 +1,377 @@
+package provider
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/rushiii/terraform-provider-zabbix/internal/zabbix"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// User type names, indexed by Zabbix role type code (1..3).
+var userRoleTypes = []string{"", "user", "admin", "super_admin"}
+
+var (
+	_ resource.Resource                = &userRoleResource{}
+	_ resource.ResourceWithConfigure   = &userRoleResource{}
+	_ resource.ResourceWithImportState = &userRoleResource{}
+)
+
+type userRoleResource struct {
+	client *zabbix.Client
+}
+
+type userRoleResourceModel struct {
+	ID                   types.String          `tfsdk:"id"`
+	Name                 types.String          `tfsdk:"name"`
+	Type                 types.String          `tfsdk:"type"`
+	ReadOnly             types.Bool            `tfsdk:"readonly"`
+	UIDefaultAccess      types.Bool            `tfsdk:"ui_default_access"`
+	ModulesDefaultAccess types.Bool            `tfsdk:"modules_default_access"`
+	APIAccess            types.Bool            `tfsdk:"api_access"`
+	APIMode              types.String          `tfsdk:"api_mode"`
+	APIMethods           types.Set             `tfsdk:"api_methods"`
+	ActionsDefaultAccess types.Bool            `tfsdk:"actions_default_access"`
+	UI                   []userRoleRuleModel   `tfsdk:"ui"`
+	Modules              []userRoleModuleModel `tfsdk:"module"`
+	Actions              []userRoleRuleModel   `tfsdk:"action"`
+}
+
+type userRoleRuleModel struct {
+	Name    types.String `tfsdk:"name"`
+	Enabled types.Bool   `tfsdk:"enabled"`
+}
+
+type userRoleModuleModel struct {
+	ModuleID types.String `tfsdk:"module_id"`
+	Enabled  types.Bool   `tfsdk:"enabled"`
+}
+
+func NewUserRoleResource() resource.Resource {
+	return &userRoleResource{}
+}
+
+func (r *userRoleResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_user_role"
+}
+
+func userRoleRuleBlock(description, nameDescription string) schema.SetNestedBlock {
+	return schema.SetNestedBlock{
+		MarkdownDescription: description,
+		NestedObject: schema.NestedBlockObject{
+			Attributes: map[string]schema.Attribute{
+				"name": schema.StringAttribute{
+					Required:            true,
+					MarkdownDescription: nameDescription,
+				},
+				"enabled": schema.BoolAttribute{
+					Required:            true,
+					MarkdownDescription: "Whether access is granted.",
+				},
+			},
+		},
+	}
+}
+
+func (r *userRoleResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Zabbix user role: user type plus UI, module, API and action access rules.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Role name.",
+			},
+			"type": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString("user"),
+				MarkdownDescription: "User type granted by the role: `user`, `admin` or `super_admin`.",
+				Validators: []validator.String{
+					stringOneOf(userRoleTypes[1:]...),
+				},
+			},
+			"readonly": schema.BoolAttribute{
+				Computed:            true,
+				MarkdownDescription: "Whether the role is a built-in read-only role.",
+			},
+			"ui_default_access": schema.BoolAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(true),
+				MarkdownDescription: "Access to UI elements not listed in `ui` blocks.",
+			},
+			"modules_default_access": schema.BoolAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(true),
+				MarkdownDescription: "Access to frontend modules not listed in `module` blocks.",
+			},
+			"api_access": schema.BoolAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(true),
+				MarkdownDescription: "Whether the API is enabled for users of the role.",
+			},
+			"api_mode": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString("deny"),
+				MarkdownDescription: "How `api_methods` is applied: `deny` (deny list) or `allow` (allow list).",
+				Validators: []validator.String{
+					stringOneOf("deny", "allow"),
+				},
+			},
+			"api_methods": schema.SetAttribute{
+				Optional:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "API methods to allow or deny, wildcards supported (e.g. `host.*`, `*.get`).",
+			},
+			"actions_default_access": schema.BoolAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(true),
+				MarkdownDescription: "Permission for actions not listed in `action` blocks.",
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"ui": userRoleRuleBlock(
+				"Access to a UI element.",
+				"UI element, e.g. `monitoring.hosts`, `configuration.templates`, `reports.audit`.",
+			),
+			"module": schema.SetNestedBlock{
+				MarkdownDescription: "Access to a frontend module.",
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"module_id": schema.StringAttribute{
+							Required:            true,
+							MarkdownDescription: "Module ID.",
+						},
+						"enabled": schema.BoolAttribute{
+							Required:            true,
+							MarkdownDescription: "Whether access is granted.",
+						},
+					},
+				},
+			},
+			"action": userRoleRuleBlock(
+				"Permission for an action.",
+				"Action, e.g. `edit_dashboards`, `acknowledge_problems`, `execute_scripts`.",
+			),
+		},
+	}
+}
+
+func (r *userRoleResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	providerData, ok := req.ProviderData.(*providerData)
+	if !ok || providerData.Client == nil {
+		resp.Diagnostics.AddError("Invalid provider", "Zabbix client unavailable.")
+		return
+	}
+	r.client = providerData.Client
+}
+
+func (r *userRoleResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan userRoleResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	zreq, d := expandUserRole(ctx, plan)
+	resp.Diagnostics.Append(d...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	id, err := r.client.RoleCreate(ctx, zreq)
+	if err != nil {
+		resp.Diagnostics.AddError("role.create error", err.Error())
+		return
+	}
+	plan.ID = types.StringValue(id)
+	plan.ReadOnly = types.BoolValue(false)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *userRoleResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state userRoleResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	role, err := r.client.RoleGetByID(ctx, state.ID.ValueString())
+	if err != nil {
+		if zabbix.IsNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError("role.get error", err.Error())
+		return
+	}
+
+	state.Name = types.StringValue(role.Name)
+	state.Type = types.StringValue(userRoleTypeName(role.Type))
+	state.ReadOnly = types.BoolValue(role.ReadOnly == "1")
+
+	rules := role.Rules
+	state.UIDefaultAccess = types.BoolValue(rules.UIDefaultAccess != "0")
+	state.ModulesDefaultAccess = types.BoolValue(rules.ModulesDefaultAccess != "0")
+	state.APIAccess = types.BoolValue(rules.APIAccess != "0")
+	if rules.APIMode == "1" {
+		state.APIMode = types.StringValue("allow")
+	} else {
+		state.APIMode = types.StringValue("deny")
+	}
+	if len(rules.API) > 0 {
+		state.APIMethods, _ = types.SetValueFrom(ctx, types.StringType, rules.API)
+	} else {
+		state.APIMethods = types.SetNull(types.StringType)
+	}
+	state.ActionsDefaultAccess = types.BoolValue(rules.ActionsDefaultAccess != "0")
+
+	// role.get lists every UI element and action with its effective status; only keep the ones
+	// that differ from the default access or that are already tracked in state, to avoid drift.
+	state.UI = flattenUserRoleRules(rules.UI, state.UIDefaultAccess.ValueBool(), state.UI)
+	state.Actions = flattenUserRoleRules(rules.Actions, state.ActionsDefaultAccess.ValueBool(), state.Actions)
+
+	tracked := make(map[string]struct{}, len(state.Modules))
+	for _, m := range state.Modules {
+		tracked[m.ModuleID.ValueString()] = struct{}{}
+	}
+	modules := make([]userRoleModuleModel, 0, len(rules.Modules))
+	for _, m := range rules.Modules {
+		enabled := m.Status == "1"
+		if _, ok := tracked[m.ModuleID]; !ok && enabled == state.ModulesDefaultAccess.ValueBool() {
+			continue
+		}
+		modules = append(modules, userRoleModuleModel{
+			ModuleID: types.StringValue(m.ModuleID),
+			Enabled:  types.BoolValue(enabled),
+		})
+	}
+	state.Modules = modules
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *userRoleResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan userRoleResourceModel
+	var state userRoleResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	zreq, d := expandUserRole(ctx, plan)
+	resp.Diagnostics.Append(d...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if err := r.client.RoleUpdate(ctx, state.ID.ValueString(), zreq); err != nil {
+		resp.Diagnostics.AddError("role.update error", err.Error())
+		return
+	}
+	plan.ID = state.ID
+	plan.ReadOnly = state.ReadOnly
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *userRoleResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state userRoleResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	err := r.client.RoleDelete(ctx, state.ID.ValueString())
+	if err != nil && !zabbix.IsNotFound(err) {
+		resp.Diagnostics.AddError("role.delete error", err.Error())
+	}
+}
+
+func (r *userRoleResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+func expandUserRole(ctx context.Context, plan userRoleResourceModel) (zabbix.RoleCreateRequest, diag.Diagnostics) {
+	methods, diags := setToStringsOptional(ctx, plan.APIMethods)
+
+	modules := make([]zabbix.RoleModuleRule, 0, len(plan.Modules))
+	for _, m := range plan.Modules {
+		modules = append(modules, zabbix.RoleModuleRule{
+			ModuleID: m.ModuleID.ValueString(),
+			Status:   strconv.Itoa(zabbix.BoolToInt(m.Enabled.ValueBool())),
+		})
+	}
+
+	apiMode := "0"
+	if plan.APIMode.ValueString() == "allow" {
+		apiMode = "1"
+	}
+
+	return zabbix.RoleCreateRequest{
+		Name: plan.Name.ValueString(),
+		Type: int(atoi64(nameToCode(userRoleTypes, plan.Type.ValueString()))),
+		Rules: zabbix.RoleRules{
+			UI:                   expandUserRoleRules(plan.UI),
+			UIDefaultAccess:      strconv.Itoa(zabbix.BoolToInt(plan.UIDefaultAccess.ValueBool())),
+			Modules:              modules,
+			ModulesDefaultAccess: strconv.Itoa(zabbix.BoolToInt(plan.ModulesDefaultAccess.ValueBool())),
+			APIAccess:            strconv.Itoa(zabbix.BoolToInt(plan.APIAccess.ValueBool())),
+			APIMode:              apiMode,
+			API:                  methods,
+			Actions:              expandUserRoleRules(plan.Actions),
+			ActionsDefaultAccess: strconv.Itoa(zabbix.BoolToInt(plan.ActionsDefaultAccess.ValueBool())),
+		},
+	}, diags
+}
+
+func expandUserRoleRules(in []userRoleRuleModel) []zabbix.RoleRule {
+	out := make([]zabbix.RoleRule, 0, len(in))
+	for _, r := range in {
+		out = append(out, zabbix.RoleRule{
+			Name:   r.Name.ValueString(),
+			Status: strconv.Itoa(zabbix.BoolToInt(r.Enabled.ValueBool())),
+		})
+	}
+	return out
+}
+
+func flattenUserRoleRules(in []zabbix.RoleRule, defaultAccess bool, prior []userRoleRuleModel) []userRoleRuleModel {
+	tracked := make(map[string]struct{}, len(prior))
+	for _, p := range prior {
+		tracked[p.Name.ValueString()] = struct{}{}
+	}
+	out := make([]userRoleRuleModel, 0, len(in))
+	for _, r := range in {
+		enabled := r.Status == "1"
+		if _, ok := tracked[r.Name]; !ok && enabled == defaultAccess {
+			continue
+		}
+		out = append(out, userRoleRuleModel{
+			Name:    types.StringValue(r.Name),
+			Enabled: types.BoolValue(enabled),
+		})
+	}
+	return out
+}
+
+func userRoleTypeName(code string) string {
+	return codeToName(userRoleTypes, code, "user")
+}
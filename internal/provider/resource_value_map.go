@@ -0,0 +1,245 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/rushiii/terraform-provider-zabbix/internal/zabbix"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// valueMapMappingTypes are the mapping types, indexed by Zabbix code.
+var valueMapMappingTypes = []string{"equal", "greater_or_equal", "less_or_equal", "range", "regexp", "default"}
+
+var (
+	_ resource.Resource                   = &valueMapResource{}
+	_ resource.ResourceWithConfigure      = &valueMapResource{}
+	_ resource.ResourceWithImportState    = &valueMapResource{}
+	_ resource.ResourceWithValidateConfig = &valueMapResource{}
+)
+
+type valueMapResource struct {
+	client *zabbix.Client
+}
+
+type valueMapResourceModel struct {
+	ID       types.String           `tfsdk:"id"`
+	HostID   types.String           `tfsdk:"host_id"`
+	Name     types.String           `tfsdk:"name"`
+	Mappings []valueMapMappingModel `tfsdk:"mapping"`
+}
+
+type valueMapMappingModel struct {
+	Type     types.String `tfsdk:"type"`
+	Value    types.String `tfsdk:"value"`
+	NewValue types.String `tfsdk:"new_value"`
+}
+
+func NewValueMapResource() resource.Resource {
+	return &valueMapResource{}
+}
+
+func (r *valueMapResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_value_map"
+}
+
+func (r *valueMapResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Zabbix value map resource: human-readable names for item values (e.g. SNMP ifOperStatus 1 => up). Items reference it with `value_map_id`.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"host_id": schema.StringAttribute{
+				Required: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				MarkdownDescription: "ID of the host or template the value map belongs to. Changing this forces recreation.",
+			},
+			"name": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Value map name, unique on the host or template.",
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"mapping": schema.ListNestedBlock{
+				MarkdownDescription: "Mapping, checked in block order: the first matching mapping applies. At least one is required.",
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"type": schema.StringAttribute{
+							Optional:            true,
+							Computed:            true,
+							Default:             stringdefault.StaticString("equal"),
+							MarkdownDescription: "`equal`, `greater_or_equal`, `less_or_equal`, `range` (e.g. `1-10,20`), `regexp` or `default` (values matching no other mapping). Types other than `equal` need Zabbix 6.0+.",
+							Validators: []validator.String{
+								stringOneOf(valueMapMappingTypes...),
+							},
+						},
+						"value": schema.StringAttribute{
+							Optional:            true,
+							MarkdownDescription: "Original value, range or regular expression. Not used by `default` mappings.",
+						},
+						"new_value": schema.StringAttribute{
+							Required:            true,
+							MarkdownDescription: "Value shown instead.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (r *valueMapResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	providerData, ok := req.ProviderData.(*providerData)
+	if !ok || providerData.Client == nil {
+		resp.Diagnostics.AddError("Invalid provider", "Zabbix client unavailable.")
+		return
+	}
+	r.client = providerData.Client
+}
+
+func (r *valueMapResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config valueMapResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if len(config.Mappings) == 0 {
+		resp.Diagnostics.AddAttributeError(path.Root("mapping"), "Missing block", "At least one `mapping` block is required.")
+	}
+	defaults := 0
+	for i, m := range config.Mappings {
+		if m.Type.IsUnknown() || m.Value.IsUnknown() {
+			continue
+		}
+		mappingPath := path.Root("mapping").AtListIndex(i)
+		if m.Type.ValueString() == "default" {
+			defaults++
+			if defaults > 1 {
+				resp.Diagnostics.AddAttributeError(mappingPath.AtName("type"), "Invalid attribute combination", "Only one `default` mapping is allowed.")
+			}
+			if !m.Value.IsNull() {
+				resp.Diagnostics.AddAttributeError(mappingPath.AtName("value"), "Invalid attribute combination", "`default` mappings have no `value`.")
+			}
+			continue
+		}
+		if m.Value.IsNull() {
+			resp.Diagnostics.AddAttributeError(mappingPath.AtName("value"), "Missing attribute", fmt.Sprintf("`%s` mappings require `value`.", m.Type.ValueString()))
+		}
+	}
+}
+
+func (r *valueMapResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan valueMapResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	id, err := r.client.ValueMapCreate(ctx, plan.HostID.ValueString(), plan.Name.ValueString(), expandValueMapMappings(plan.Mappings))
+	if err != nil {
+		resp.Diagnostics.AddError("valuemap.create error", err.Error())
+		return
+	}
+
+	plan.ID = types.StringValue(id)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *valueMapResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state valueMapResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	valueMap, err := r.client.ValueMapGetByID(ctx, state.ID.ValueString())
+	if err != nil {
+		if zabbix.IsNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError("valuemap.get error", err.Error())
+		return
+	}
+
+	state.HostID = types.StringValue(valueMap.HostID)
+	state.Name = types.StringValue(valueMap.Name)
+	state.Mappings = make([]valueMapMappingModel, 0, len(valueMap.Mappings))
+	for _, m := range valueMap.Mappings {
+		mappingType := codeToName(valueMapMappingTypes, m.Type, "equal")
+		value := types.StringValue(m.Value)
+		if mappingType == "default" {
+			value = types.StringNull()
+		}
+		state.Mappings = append(state.Mappings, valueMapMappingModel{
+			Type:     types.StringValue(mappingType),
+			Value:    value,
+			NewValue: types.StringValue(m.NewValue),
+		})
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *valueMapResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan valueMapResourceModel
+	var state valueMapResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.ValueMapUpdate(ctx, state.ID.ValueString(), plan.Name.ValueString(), expandValueMapMappings(plan.Mappings))
+	if err != nil {
+		resp.Diagnostics.AddError("valuemap.update error", err.Error())
+		return
+	}
+
+	plan.ID = state.ID
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *valueMapResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state valueMapResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	err := r.client.ValueMapDelete(ctx, state.ID.ValueString())
+	if err != nil && !zabbix.IsNotFound(err) {
+		resp.Diagnostics.AddError("valuemap.delete error", err.Error())
+	}
+}
+
+func (r *valueMapResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+func expandValueMapMappings(mappings []valueMapMappingModel) []zabbix.ValueMapMapping {
+	out := make([]zabbix.ValueMapMapping, 0, len(mappings))
+	for _, m := range mappings {
+		out = append(out, zabbix.ValueMapMapping{
+			Type:     nameToCode(valueMapMappingTypes, m.Type.ValueString()),
+			Value:    nullableString(m.Value),
+			NewValue: m.NewValue.ValueString(),
+		})
+	}
+	return out
+}
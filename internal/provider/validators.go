@@ -0,0 +1,84 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/rushiii/terraform-provider-zabbix/internal/zabbix"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+var _ validator.String = stringOneOfValidator{}
+
+// stringOneOfValidator rejects values outside a fixed set of allowed strings at plan time.
+type stringOneOfValidator struct {
+	allowed []string
+}
+
+func stringOneOf(allowed ...string) stringOneOfValidator {
+	return stringOneOfValidator{allowed: allowed}
+}
+
+func (v stringOneOfValidator) Description(_ context.Context) string {
+	return fmt.Sprintf("value must be one of: %s", strings.Join(v.allowed, ", "))
+}
+
+func (v stringOneOfValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v stringOneOfValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+	value := req.ConfigValue.ValueString()
+	for _, a := range v.allowed {
+		if value == a {
+			return
+		}
+	}
+	resp.Diagnostics.AddAttributeError(
+		req.Path,
+		"Invalid value",
+		fmt.Sprintf("Got %q, %s.", value, v.Description(ctx)),
+	)
+}
+
+var _ validator.String = triggerExpressionValidator{}
+
+// triggerExpressionValidator checks the syntax of a Zabbix 5.4+ expression at plan time.
+type triggerExpressionValidator struct{}
+
+func triggerExpression() triggerExpressionValidator {
+	return triggerExpressionValidator{}
+}
+
+func (v triggerExpressionValidator) Description(_ context.Context) string {
+	return "value must be a valid Zabbix expression"
+}
+
+func (v triggerExpressionValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v triggerExpressionValidator) ValidateString(_ context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+	value := req.ConfigValue.ValueString()
+	if _, err := zabbix.ParseExpression(value); err != nil {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid expression", expressionErrorDetail(value, err))
+	}
+}
+
+// expressionErrorDetail formats an expression parse error, pointing at the offset on single-line expressions.
+func expressionErrorDetail(value string, err error) string {
+	var exprErr *zabbix.ExpressionError
+	if errors.As(err, &exprErr) && !strings.Contains(value, "\n") {
+		return fmt.Sprintf("%s:\n  %s\n  %s^", exprErr.Error(), value, strings.Repeat(" ", exprErr.Offset))
+	}
+	return err.Error()
+}
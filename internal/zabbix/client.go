@@ -9,7 +9,9 @@ import (
 	"fmt"
 	"io"
 	"log"
+	"net"
 	"net/http"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
@@ -78,6 +80,7 @@ type Client struct {
 	mu          sync.Mutex
 	sessionAuth string
 	rpcID       int64
+	apiVersion  string
 }
 
 type rpcRequest struct {
@@ -167,8 +170,48 @@ func (c *Client) ensureAuth(ctx context.Context) (string, error) {
 }
 
 func (c *Client) Ping(ctx context.Context) error {
+	_, err := c.APIVersion(ctx)
+	return err
+}
+
+// APIVersion returns the server API version (e.g. "6.4.12"), cached after the first call.
+func (c *Client) APIVersion(ctx context.Context) (string, error) {
+	c.mu.Lock()
+	if c.apiVersion != "" {
+		version := c.apiVersion
+		c.mu.Unlock()
+		return version, nil
+	}
+	c.mu.Unlock()
+
 	var version string
-	return c.callNoAuth(ctx, "apiinfo.version", map[string]any{}, &version)
+	if err := c.callNoAuth(ctx, "apiinfo.version", map[string]any{}, &version); err != nil {
+		return "", err
+	}
+
+	c.mu.Lock()
+	c.apiVersion = version
+	c.mu.Unlock()
+
+	return version, nil
+}
+
+// VersionAtLeast reports whether the server API version is >= major.minor.
+func (c *Client) VersionAtLeast(ctx context.Context, major, minor int) (bool, error) {
+	version, err := c.APIVersion(ctx)
+	if err != nil {
+		return false, err
+	}
+	parts := strings.SplitN(version, ".", 3)
+	gotMajor, _ := strconv.Atoi(parts[0])
+	gotMinor := 0
+	if len(parts) > 1 {
+		gotMinor, _ = strconv.Atoi(parts[1])
+	}
+	if gotMajor != major {
+		return gotMajor > major, nil
+	}
+	return gotMinor >= minor, nil
 }
 
 func (c *Client) callNoAuth(ctx context.Context, method string, params interface{}, out interface{}) error {
@@ -179,10 +222,49 @@ func (c *Client) callAuth(ctx context.Context, method string, params interface{}
 	return c.call(ctx, method, params, true, out)
 }
 
+// redactedParams are the secret params masked in debug logs.
+var redactedParams = map[string]bool{
+	"tls_psk":          true,
+	"tls_psk_identity": true,
+	"authpassphrase":   true,
+	"privpassphrase":   true,
+}
+
+// redactParams returns a copy of params with the values of redactedParams masked, at any depth.
+func redactParams(params any) any {
+	b, err := json.Marshal(params)
+	if err != nil {
+		return nil
+	}
+	var out any
+	if err := json.Unmarshal(b, &out); err != nil {
+		return nil
+	}
+	var redact func(v any)
+	redact = func(v any) {
+		switch x := v.(type) {
+		case map[string]any:
+			for k, item := range x {
+				if redactedParams[k] {
+					x[k] = "***"
+					continue
+				}
+				redact(item)
+			}
+		case []any:
+			for _, item := range x {
+				redact(item)
+			}
+		}
+	}
+	redact(out)
+	return out
+}
+
 func (c *Client) call(ctx context.Context, method string, params interface{}, withAuth bool, out interface{}) error {
 	if method == "host.create" || method == "host.update" || method == "hostinterface.replacehostinterfaces" ||
 		method == "hostinterface.update" || method == "hostinterface.create" || method == "hostinterface.delete" {
-		if b, err := json.Marshal(params); err == nil {
+		if b, err := json.Marshal(redactParams(params)); err == nil {
 			log.Printf("zabbix client debug: %s params=%s", method, string(b))
 		}
 	}
@@ -246,14 +328,52 @@ type Tag struct {
 
 type SNMPDetails struct {
 	// Zabbix 6.4 returns version as a JSON string ("2"), flexInt handles both string and number.
-	Version   FlexInt `json:"version,omitempty"`
-	Community string  `json:"community,omitempty"`
-	Security  string  `json:"securityname,omitempty"`
-	AuthProto string  `json:"authprotocol,omitempty"`
-	AuthPass  string  `json:"authpassphrase,omitempty"`
-	PrivProto string  `json:"privprotocol,omitempty"`
-	PrivPass  string  `json:"privpassphrase,omitempty"`
-	Context   string  `json:"contextname,omitempty"`
+	Version        FlexInt `json:"version,omitempty"`
+	Bulk           FlexInt `json:"bulk,omitempty"`
+	MaxRepetitions FlexInt `json:"max_repetitions,omitempty"` // 7.0+
+	Community      string  `json:"community,omitempty"`
+	Security       string  `json:"securityname,omitempty"`
+	SecurityLevel  FlexInt `json:"securitylevel,omitempty"`
+	AuthProto      FlexInt `json:"authprotocol,omitempty"`
+	AuthPass       string  `json:"authpassphrase,omitempty"`
+	PrivProto      FlexInt `json:"privprotocol,omitempty"`
+	PrivPass       string  `json:"privpassphrase,omitempty"`
+	Context        string  `json:"contextname,omitempty"`
+}
+
+// SNMPv3 security levels.
+const (
+	SNMPSecurityNoAuthNoPriv = 0
+	SNMPSecurityAuthNoPriv   = 1
+	SNMPSecurityAuthPriv     = 2
+)
+
+// snmpDetailsParam builds the details of an SNMP interface with the fields of its version;
+// max_repetitions is only sent to Zabbix 7.0+.
+func snmpDetailsParam(d *SNMPDetails, maxRepetitions bool) map[string]any {
+	m := map[string]any{
+		"version": int(d.Version),
+		"bulk":    int(d.Bulk),
+	}
+	if maxRepetitions && d.MaxRepetitions != 0 {
+		m["max_repetitions"] = int(d.MaxRepetitions)
+	}
+	if d.Version != 3 {
+		m["community"] = d.Community
+		return m
+	}
+	m["securityname"] = d.Security
+	m["securitylevel"] = int(d.SecurityLevel)
+	m["contextname"] = d.Context
+	if d.SecurityLevel != SNMPSecurityNoAuthNoPriv {
+		m["authprotocol"] = int(d.AuthProto)
+		m["authpassphrase"] = d.AuthPass
+	}
+	if d.SecurityLevel == SNMPSecurityAuthPriv {
+		m["privprotocol"] = int(d.PrivProto)
+		m["privpassphrase"] = d.PrivPass
+	}
+	return m
 }
 
 // parseInt accepts JSON number or string for Zabbix API compatibility (some versions return main/type/useip as string).
@@ -362,8 +482,24 @@ type Host struct {
 		Name       string `json:"name"`
 	} `json:"parentTemplates"`
 	Tags []Tag `json:"tags"`
+
+	MonitoredBy  int
+	ProxyID      string
+	ProxyGroupID string
+
+	TLSConnect int
+	TLSAccept  int
+	TLSIssuer  string
+	TLSSubject string
 }
 
+// Host monitored_by values (Zabbix 7.0+; before, hosts are monitored by the server or proxy_hostid).
+const (
+	HostMonitoredByServer     = 0
+	HostMonitoredByProxy      = 1
+	HostMonitoredByProxyGroup = 2
+)
+
 type hostJSON struct {
 	HostID   interface{} `json:"hostid"`   // API can return string or number
 	Host     string      `json:"host"`
@@ -380,6 +516,16 @@ type hostJSON struct {
 		Name       string `json:"name"`
 	} `json:"parentTemplates"`
 	Tags []Tag `json:"tags"`
+
+	MonitoredBy  FlexInt `json:"monitored_by"`
+	ProxyID      string  `json:"proxyid"`
+	ProxyGroupID string  `json:"proxy_groupid"`
+	ProxyHostID  string  `json:"proxy_hostid"` // before 7.0
+
+	TLSConnect FlexInt `json:"tls_connect"`
+	TLSAccept  FlexInt `json:"tls_accept"`
+	TLSIssuer  string  `json:"tls_issuer"`
+	TLSSubject string  `json:"tls_subject"`
 }
 
 func (h *Host) UnmarshalJSON(data []byte) error {
@@ -395,6 +541,17 @@ func (h *Host) UnmarshalJSON(data []byte) error {
 	h.Groups = raw.Groups
 	h.ParentTemplates = raw.ParentTemplates
 	h.Tags = raw.Tags
+	h.MonitoredBy = int(raw.MonitoredBy)
+	h.ProxyID = raw.ProxyID
+	h.ProxyGroupID = raw.ProxyGroupID
+	if raw.ProxyHostID != "" && raw.ProxyHostID != "0" {
+		h.MonitoredBy = HostMonitoredByProxy
+		h.ProxyID = raw.ProxyHostID
+	}
+	h.TLSConnect = int(raw.TLSConnect)
+	h.TLSAccept = int(raw.TLSAccept)
+	h.TLSIssuer = raw.TLSIssuer
+	h.TLSSubject = raw.TLSSubject
 	return nil
 }
 
@@ -406,13 +563,63 @@ type HostCreateRequest struct {
 	GroupIDs    []string
 	TemplateIDs []string
 	Tags        []Tag
+
+	MonitoredBy  int
+	ProxyID      string
+	ProxyGroupID string
+
+	TLSConnect     int
+	TLSAccept      int
+	TLSIssuer      string
+	TLSSubject     string
+	TLSPSKIdentity string
+	TLSPSK         string
 }
 
 type HostUpdateRequest = HostCreateRequest
 
+// hostTLSParams sets the encryption of the connections with the agent. The PSK identity and key are only
+// sent together, with a PSK connection and a key: without a key, Zabbix keeps the current ones.
+func hostTLSParams(params map[string]any, req HostCreateRequest) {
+	params["tls_connect"] = req.TLSConnect
+	params["tls_accept"] = req.TLSAccept
+	params["tls_issuer"] = req.TLSIssuer
+	params["tls_subject"] = req.TLSSubject
+	if (req.TLSConnect|req.TLSAccept)&TLSPSK != 0 && req.TLSPSK != "" {
+		params["tls_psk_identity"] = req.TLSPSKIdentity
+		params["tls_psk"] = req.TLSPSK
+	}
+}
+
+// hostMonitoringParams sets the proxy or proxy group monitoring the host; unused IDs are reset to "0".
+func (c *Client) hostMonitoringParams(ctx context.Context, params map[string]any, req HostCreateRequest) error {
+	v70, err := c.VersionAtLeast(ctx, 7, 0)
+	if err != nil {
+		return err
+	}
+	proxyID, proxyGroupID := "0", "0"
+	switch req.MonitoredBy {
+	case HostMonitoredByProxy:
+		proxyID = req.ProxyID
+	case HostMonitoredByProxyGroup:
+		proxyGroupID = req.ProxyGroupID
+	}
+	if v70 {
+		params["monitored_by"] = req.MonitoredBy
+		params["proxyid"] = proxyID
+		params["proxy_groupid"] = proxyGroupID
+		return nil
+	}
+	if req.MonitoredBy == HostMonitoredByProxyGroup {
+		return errors.New("proxy groups require Zabbix 7.0 or later")
+	}
+	params["proxy_hostid"] = proxyID
+	return nil
+}
+
 // interfacesForHostCreate builds the interfaces payload for host.create so it matches Zabbix API expectations:
-// no interfaceid, dns always set, SNMP details with version/community/bulk.
-func interfacesForHostCreate(ifaces []HostInterface) []map[string]any {
+// no interfaceid, dns always set, SNMP details with the fields of their version.
+func interfacesForHostCreate(ifaces []HostInterface, maxRepetitions bool) []map[string]any {
 	out := make([]map[string]any, 0, len(ifaces))
 	for _, i := range ifaces {
 		m := map[string]any{
@@ -424,11 +631,7 @@ func interfacesForHostCreate(ifaces []HostInterface) []map[string]any {
 			"port":  i.Port,
 		}
 		if i.Type == 2 && i.Details != nil {
-			m["details"] = map[string]any{
-				"version":   int(i.Details.Version),
-				"community": i.Details.Community,
-				"bulk":      1,
-			}
+			m["details"] = snmpDetailsParam(i.Details, maxRepetitions)
 		}
 		out = append(out, m)
 	}
@@ -437,7 +640,7 @@ func interfacesForHostCreate(ifaces []HostInterface) []map[string]any {
 
 // interfacesForHostUpdate builds the interfaces payload for host.update: same as create but with
 // interfaceid set for existing interfaces (type,main) so the API updates in place and applies details.
-func interfacesForHostUpdate(ifaces []HostInterface, currentByTypeMain map[string]string) []map[string]any {
+func interfacesForHostUpdate(ifaces []HostInterface, currentByTypeMain map[string]string, maxRepetitions bool) []map[string]any {
 	out := make([]map[string]any, 0, len(ifaces))
 	for _, i := range ifaces {
 		m := map[string]any{
@@ -453,11 +656,7 @@ func interfacesForHostUpdate(ifaces []HostInterface, currentByTypeMain map[strin
 			m["interfaceid"] = id
 		}
 		if i.Type == 2 && i.Details != nil {
-			m["details"] = map[string]any{
-				"version":   int(i.Details.Version),
-				"community": i.Details.Community,
-				"bulk":      1,
-			}
+			m["details"] = snmpDetailsParam(i.Details, maxRepetitions)
 		}
 		out = append(out, m)
 	}
@@ -489,6 +688,10 @@ func (c *Client) HostCreate(ctx context.Context, req HostCreateRequest) (string,
 		templates = append(templates, map[string]string{"templateid": t})
 	}
 
+	maxRepetitions, err := c.VersionAtLeast(ctx, 7, 0)
+	if err != nil {
+		return "", err
+	}
 	interfacesToSend := req.Interfaces
 	skipTemplatesOnCreate := false
 	if allInterfacesAreSNMP(req.Interfaces) {
@@ -508,7 +711,7 @@ func (c *Client) HostCreate(ctx context.Context, req HostCreateRequest) (string,
 		"host":       req.Host,
 		"name":       req.Name,
 		"status":     req.Status,
-		"interfaces": interfacesForHostCreate(interfacesToSend),
+		"interfaces": interfacesForHostCreate(interfacesToSend, maxRepetitions),
 		"groups":     groups,
 	}
 	if len(req.Tags) > 0 {
@@ -517,6 +720,10 @@ func (c *Client) HostCreate(ctx context.Context, req HostCreateRequest) (string,
 	if len(templates) > 0 && !skipTemplatesOnCreate {
 		params["templates"] = templates
 	}
+	if err := c.hostMonitoringParams(ctx, params, req); err != nil {
+		return "", err
+	}
+	hostTLSParams(params, req)
 
 	var result struct {
 		HostIDs []string `json:"hostids"`
@@ -551,11 +758,7 @@ func (c *Client) HostCreate(ctx context.Context, req HostCreateRequest) (string,
 				"port":   i.Port,
 			}
 			if i.Details != nil {
-				payload["details"] = map[string]any{
-					"version":   int(i.Details.Version),
-					"community": i.Details.Community,
-					"bulk":      1,
-				}
+				payload["details"] = snmpDetailsParam(i.Details, maxRepetitions)
 			}
 			if err := c.callAuth(ctx, "hostinterface.create", payload, &ignored); err != nil {
 				return "", fmt.Errorf("hostinterface.create (SNMP): %w", err)
@@ -573,9 +776,17 @@ func (c *Client) HostCreate(ctx context.Context, req HostCreateRequest) (string,
 }
 
 func (c *Client) HostGetByID(ctx context.Context, hostID string) (*Host, error) {
+	output := []string{"hostid", "host", "name", "status", "tls_connect", "tls_accept", "tls_issuer", "tls_subject", "proxy_hostid"}
+	v70, err := c.VersionAtLeast(ctx, 7, 0)
+	if err != nil {
+		return nil, err
+	}
+	if v70 {
+		output = []string{"hostid", "host", "name", "status", "tls_connect", "tls_accept", "tls_issuer", "tls_subject", "monitored_by", "proxyid", "proxy_groupid"}
+	}
 	params := map[string]any{
 		"hostids":               []string{hostID},
-		"output":                []string{"hostid", "host", "name", "status"},
+		"output":                output,
 		"selectInterfaces":      "extend",
 		"selectGroups":          []string{"groupid", "name"},
 		"selectParentTemplates": []string{"templateid", "host", "name"},
@@ -603,6 +814,10 @@ func (c *Client) HostUpdate(ctx context.Context, hostID string, req HostUpdateRe
 		key := fmt.Sprintf("%d,%d", iface.Type, iface.Main)
 		currentByTypeMain[key] = iface.InterfaceID
 	}
+	maxRepetitions, err := c.VersionAtLeast(ctx, 7, 0)
+	if err != nil {
+		return err
+	}
 
 	groups := make([]map[string]string, 0, len(req.GroupIDs))
 	for _, g := range req.GroupIDs {
@@ -624,7 +839,7 @@ func (c *Client) HostUpdate(ctx context.Context, hostID string, req HostUpdateRe
 		"status":     strconv.Itoa(req.Status),
 		"groups":     groups,
 		"tags":       tags,
-		"interfaces": interfacesForHostUpdate(req.Interfaces, currentByTypeMain),
+		"interfaces": interfacesForHostUpdate(req.Interfaces, currentByTypeMain, maxRepetitions),
 	}
 	if req.Name != "" {
 		params["name"] = req.Name
@@ -632,6 +847,10 @@ func (c *Client) HostUpdate(ctx context.Context, hostID string, req HostUpdateRe
 	if len(templates) > 0 {
 		params["templates"] = templates
 	}
+	if err := c.hostMonitoringParams(ctx, params, req); err != nil {
+		return err
+	}
+	hostTLSParams(params, req)
 
 	var ignored any
 	if err := c.callAuth(ctx, "host.update", params, &ignored); err != nil {
@@ -712,6 +931,21 @@ func (c *Client) HostGroupDelete(ctx context.Context, id string) error {
 	return c.callAuth(ctx, "hostgroup.delete", []string{id}, &ignored)
 }
 
+// HostGroupPropagate pushes the group's permissions and/or tag filters down to its nested subgroups
+// (e.g. "Linux" -> "Linux/Prod"). Requires Zabbix 6.2+; at least one of permissions/tagFilters must be true.
+func (c *Client) HostGroupPropagate(ctx context.Context, id string, permissions, tagFilters bool) error {
+	if !permissions && !tagFilters {
+		return nil
+	}
+	params := map[string]any{
+		"groups":      []map[string]string{{"groupid": id}},
+		"permissions": permissions,
+		"tag_filters": tagFilters,
+	}
+	var ignored any
+	return c.callAuth(ctx, "hostgroup.propagate", params, &ignored)
+}
+
 type Template struct {
 	TemplateID string `json:"templateid"`
 	Host       string `json:"host"`
@@ -849,19 +1083,98 @@ func (c *Client) TemplateDelete(ctx context.Context, id string) error {
 }
 
 type Trigger struct {
-	TriggerID   string `json:"triggerid"`
-	Description string `json:"description"`
-	Expression  string `json:"expression"`
-	Priority    string `json:"priority"`
-	Status      string `json:"status"`
+	TriggerID          string `json:"triggerid"`
+	Description        string `json:"description"`
+	Expression         string `json:"expression"`
+	Priority           string `json:"priority"`
+	Status             string `json:"status"`
+	RecoveryMode       string `json:"recovery_mode"` // 0=expression, 1=recovery expression, 2=none
+	RecoveryExpression string `json:"recovery_expression"`
+	ManualClose        string `json:"manual_close"`
+	Type               string `json:"type"` // 0=single event, 1=multiple events
+	EventName          string `json:"event_name"`
+	Opdata             string `json:"opdata"`
+	Comments           string `json:"comments"`
+	URL                string `json:"url"`
+	URLName            string `json:"url_name"`         // 7.0+
+	CorrelationMode    string `json:"correlation_mode"` // 0=all problems, 1=problems with matching tag
+	CorrelationTag     string `json:"correlation_tag"`
+	Tags               []Tag  `json:"tags"`
+	Dependencies       []struct {
+		TriggerID string `json:"triggerid"`
+	} `json:"dependencies"`
+}
+
+// Trigger recovery modes (recovery_mode).
+const (
+	TriggerRecoveryExpression         = 0
+	TriggerRecoveryRecoveryExpression = 1
+	TriggerRecoveryNone               = 2
+)
+
+// TriggerCreateRequest for creating or updating a trigger.
+type TriggerCreateRequest struct {
+	Description        string
+	Expression         string
+	Priority           string // "0".."5"
+	Enabled            bool
+	RecoveryMode       int    // TriggerRecovery*
+	RecoveryExpression string // with TriggerRecoveryRecoveryExpression
+	ManualClose        bool
+	MultipleEvents     bool // generate a problem event on every evaluation to PROBLEM (type 1)
+	EventName          string
+	Opdata             string
+	Comments           string
+	URL                string
+	URLName            string // sent on Zabbix 7.0+ only
+	Tags               []Tag
+	CorrelationMode    int    // 0=all problems, 1=problems with matching tag
+	CorrelationTag     string // with CorrelationMode 1
+	DependencyIDs      []string
 }
 
-func (c *Client) TriggerCreate(ctx context.Context, description, expression, priority string, enabled bool) (string, error) {
+// triggerParams builds the fields shared by trigger.create and trigger.update.
+func (c *Client) triggerParams(ctx context.Context, req TriggerCreateRequest) (map[string]any, error) {
+	tags := req.Tags
+	if tags == nil {
+		tags = []Tag{}
+	}
+	dependencies := make([]map[string]string, 0, len(req.DependencyIDs))
+	for _, id := range req.DependencyIDs {
+		dependencies = append(dependencies, map[string]string{"triggerid": id})
+	}
 	params := map[string]any{
-		"description": description,
-		"expression":  expression,
-		"priority":    priority,
-		"status":      boolToStatus(enabled),
+		"description":         req.Description,
+		"expression":          req.Expression,
+		"priority":            req.Priority,
+		"status":              BoolToStatus(req.Enabled),
+		"recovery_mode":       req.RecoveryMode,
+		"recovery_expression": req.RecoveryExpression,
+		"manual_close":        BoolToInt(req.ManualClose),
+		"type":                BoolToInt(req.MultipleEvents),
+		"event_name":          req.EventName,
+		"opdata":              req.Opdata,
+		"comments":            req.Comments,
+		"url":                 req.URL,
+		"correlation_mode":    req.CorrelationMode,
+		"correlation_tag":     req.CorrelationTag,
+		"tags":                tags,
+		"dependencies":        dependencies,
+	}
+	urlName, err := c.VersionAtLeast(ctx, 7, 0)
+	if err != nil {
+		return nil, err
+	}
+	if urlName {
+		params["url_name"] = req.URLName
+	}
+	return params, nil
+}
+
+func (c *Client) TriggerCreate(ctx context.Context, req TriggerCreateRequest) (string, error) {
+	params, err := c.triggerParams(ctx, req)
+	if err != nil {
+		return "", err
 	}
 	var result struct {
 		TriggerIDs []string `json:"triggerids"`
@@ -877,9 +1190,11 @@ func (c *Client) TriggerCreate(ctx context.Context, description, expression, pri
 
 func (c *Client) TriggerGetByID(ctx context.Context, id string) (*Trigger, error) {
 	params := map[string]any{
-		"triggerids":        []string{id},
-		"output":            []string{"triggerid", "description", "expression", "priority", "status"},
-		"expandExpression": true, // return last(/Host/key) instead of {itemid} to avoid config drift
+		"triggerids":         []string{id},
+		"output":             "extend",
+		"selectTags":         "extend",
+		"selectDependencies": []string{"triggerid"},
+		"expandExpression":   true, // return last(/Host/key) instead of {itemid} to avoid config drift
 	}
 	var triggers []Trigger
 	if err := c.callAuth(ctx, "trigger.get", params, &triggers); err != nil {
@@ -891,14 +1206,12 @@ func (c *Client) TriggerGetByID(ctx context.Context, id string) (*Trigger, error
 	return &triggers[0], nil
 }
 
-func (c *Client) TriggerUpdate(ctx context.Context, id, description, expression, priority string, enabled bool) error {
-	params := map[string]any{
-		"triggerid":   id,
-		"description": description,
-		"expression":  expression,
-		"priority":    priority,
-		"status":      boolToStatus(enabled),
+func (c *Client) TriggerUpdate(ctx context.Context, id string, req TriggerCreateRequest) error {
+	params, err := c.triggerParams(ctx, req)
+	if err != nil {
+		return err
 	}
+	params["triggerid"] = id
 	var ignored any
 	return c.callAuth(ctx, "trigger.update", params, &ignored)
 }
@@ -908,37 +1221,206 @@ func (c *Client) TriggerDelete(ctx context.Context, id string) error {
 	return c.callAuth(ctx, "trigger.delete", []string{id}, &ignored)
 }
 
-// Item: 0=Zabbix agent, 1=SNMPv1, 2=SNMPv2c, 3=SNMPv3...
-// ValueType : 0=float, 1=str, 2=log, 3=unsigned, 4=text
+// Item types (type). ValueType: 0=float, 1=str, 2=log, 3=unsigned, 4=text.
+const (
+	ItemTypeZabbixAgent       = 0
+	ItemTypeTrapper           = 2
+	ItemTypeSimpleCheck       = 3
+	ItemTypeInternal          = 5
+	ItemTypeZabbixAgentActive = 7
+	ItemTypeExternal          = 10
+	ItemTypeDatabaseMonitor   = 11
+	ItemTypeIPMI              = 12
+	ItemTypeSSH               = 13
+	ItemTypeTelnet            = 14
+	ItemTypeCalculated        = 15
+	ItemTypeJMX               = 16
+	ItemTypeSNMPTrap          = 17
+	ItemTypeDependent         = 18
+	ItemTypeHTTPAgent         = 19
+	ItemTypeSNMPAgent         = 20
+	ItemTypeScript            = 21
+)
+
+// ItemPreprocessing is a preprocessing step of an item. Params holds the step parameters separated by "\n".
+type ItemPreprocessing struct {
+	Type               string `json:"type"`
+	Params             string `json:"params"`
+	ErrorHandler       string `json:"error_handler"`
+	ErrorHandlerParams string `json:"error_handler_params"`
+}
+
+// ItemField is a name/value pair of an item: HTTP agent query fields and headers, script parameters.
+type ItemField struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
 type Item struct {
-	ItemID    string  `json:"itemid"`
-	HostID    string  `json:"hostid"`
-	Name      string  `json:"name"`
-	Key       string  `json:"key_"`
-	Type      FlexInt `json:"type"`
-	ValueType FlexInt `json:"value_type"`
-	SNMPOid   string  `json:"snmp_oid"`
-	Units     string  `json:"units"`
-	Delay     string  `json:"delay"`
-	History   string  `json:"history"`
-	Trends    string  `json:"trends"`
-	DelayFlex string  `json:"delay_flex"`
-	Status    string  `json:"status"` // 0=enabled, 1=disabled
+	ItemID          string              `json:"itemid"`
+	HostID          string              `json:"hostid"`
+	InterfaceID     string              `json:"interfaceid"`
+	Name            string              `json:"name"`
+	Key             string              `json:"key_"`
+	Type            FlexInt             `json:"type"`
+	ValueType       FlexInt             `json:"value_type"`
+	SNMPOid         string              `json:"snmp_oid"`
+	Units           string              `json:"units"`
+	Delay           string              `json:"delay"`
+	History         string              `json:"history"`
+	Trends          string              `json:"trends"`
+	Status          string              `json:"status"` // 0=enabled, 1=disabled
+	Params          string              `json:"params"`
+	MasterItemID    string              `json:"master_itemid"`
+	URL             string              `json:"url"`
+	Posts           string              `json:"posts"`
+	PostType        FlexInt             `json:"post_type"`
+	RequestMethod   FlexInt             `json:"request_method"`
+	RetrieveMode    FlexInt             `json:"retrieve_mode"`
+	OutputFormat    FlexInt             `json:"output_format"`
+	StatusCodes     string              `json:"status_codes"`
+	FollowRedirects string              `json:"follow_redirects"`
+	VerifyPeer      string              `json:"verify_peer"`
+	VerifyHost      string              `json:"verify_host"`
+	AllowTraps      string              `json:"allow_traps"`
+	HTTPProxy       string              `json:"http_proxy"`
+	SSLCertFile     string              `json:"ssl_cert_file"`
+	SSLKeyFile      string              `json:"ssl_key_file"`
+	AuthType        FlexInt             `json:"authtype"`
+	Username        string              `json:"username"`
+	PublicKey       string              `json:"publickey"`
+	PrivateKey      string              `json:"privatekey"`
+	JMXEndpoint     string              `json:"jmx_endpoint"`
+	IPMISensor      string              `json:"ipmi_sensor"`
+	Timeout         string              `json:"timeout"`
+	Parameters      []ItemField         `json:"parameters"`
+	Preprocessing   []ItemPreprocessing `json:"preprocessing"`
+	Tags            []Tag               `json:"tags"`
+	Description     string              `json:"description"`
+	ValueMapID      string              `json:"valuemapid"`
+	InventoryLink   FlexInt             `json:"inventory_link"`
+	TrapperHosts    string              `json:"trapper_hosts"`
+	LogTimeFmt      string              `json:"logtimefmt"`
+	QueryFields     []ItemField         `json:"-"`
+	Headers         []ItemField         `json:"-"`
+}
+
+type itemAlias Item
+
+// UnmarshalJSON reads query_fields and headers in both the 7.0 format ([{"name": ..., "value": ...}])
+// and the older one ([{"name": "value"}] and {"name": "value"}).
+func (i *Item) UnmarshalJSON(data []byte) error {
+	var aux struct {
+		itemAlias
+		QueryFields json.RawMessage `json:"query_fields"`
+		Headers     json.RawMessage `json:"headers"`
+	}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	*i = Item(aux.itemAlias)
+	i.QueryFields = parseItemFields(aux.QueryFields)
+	i.Headers = parseItemFields(aux.Headers)
+	return nil
+}
+
+func parseItemFields(data json.RawMessage) []ItemField {
+	data = bytes.TrimSpace(data)
+	if len(data) == 0 {
+		return nil
+	}
+	if data[0] == '{' {
+		var byName map[string]string
+		if err := json.Unmarshal(data, &byName); err != nil {
+			return nil
+		}
+		names := make([]string, 0, len(byName))
+		for name := range byName {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		fields := make([]ItemField, 0, len(names))
+		for _, name := range names {
+			fields = append(fields, ItemField{Name: name, Value: byName[name]})
+		}
+		return fields
+	}
+	var entries []map[string]string
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil
+	}
+	fields := make([]ItemField, 0, len(entries))
+	for _, entry := range entries {
+		name, hasName := entry["name"]
+		value, hasValue := entry["value"]
+		if len(entry) == 2 && hasName && hasValue {
+			fields = append(fields, ItemField{Name: name, Value: value})
+			continue
+		}
+		for name, value := range entry {
+			fields = append(fields, ItemField{Name: name, Value: value})
+		}
+	}
+	return fields
 }
 
 type ItemCreateRequest struct {
-	HostID    string
-	Name      string
-	Key       string
-	Type      int    // 2 = SNMPv2 agent
-	ValueType int    // 3 = unsigned
-	SNMPOid   string
-	Units     string
-	Delay     string // ex: "10m", "60s"
-	History   string // ex: "90d"
-	Trends    string // ex: "365d"
-	DelayFlex string // ex: "50s;1-7,00:00-24:00"
-	Enabled   bool
+	HostID      string
+	InterfaceID string // empty: resolved from the item type, see itemInterfaceTypes
+	Name        string
+	Key         string
+	Type        int
+	ValueType   int // 3 = unsigned
+	SNMPOid     string
+	Units       string
+	Delay       string // ex: "10m", "60s", "10m;50s/1-5,09:00-18:00" (custom intervals after ";")
+	History     string // ex: "90d"
+	Trends      string // ex: "365d"
+	Enabled     bool
+
+	Tags          []Tag
+	Description   string
+	ValueMapID    string // empty: no value map
+	InventoryLink int    // host inventory field ID, 0: none
+	TrapperHosts  string // allowed hosts of trapper and HTTP agent items
+	LogTimeFmt    string // log items
+
+	// Formula (calculated), script (script, SSH, Telnet) or SQL query (database monitor).
+	Params       string
+	MasterItemID string // dependent items
+
+	// HTTP agent.
+	URL             string
+	QueryFields     []ItemField
+	Headers         []ItemField
+	Posts           string
+	PostType        int
+	RequestMethod   int
+	RetrieveMode    int
+	OutputFormat    int
+	StatusCodes     string
+	FollowRedirects bool
+	VerifyPeer      bool
+	VerifyHost      bool
+	AllowTraps      bool
+	HTTPProxy       string
+	SSLCertFile     string
+	SSLKeyFile      string
+	SSLKeyPassword  string
+
+	// Authentication: authtype is used by HTTP agent and SSH items, credentials by most remote checks.
+	AuthType   int
+	Username   string
+	Password   string
+	PublicKey  string
+	PrivateKey string
+
+	JMXEndpoint string
+	IPMISensor  string
+	Timeout     string      // HTTP agent and script items; on Zabbix 7.0+ every type in itemTypesWithTimeout
+	Parameters  []ItemField // script items
+
+	Preprocessing []ItemPreprocessing // in order
 }
 
 // Host interface type constants (Zabbix API).
@@ -949,14 +1431,50 @@ const (
 	HostInterfaceJMX   = 4
 )
 
+// itemInterfaceTypes lists the item types that poll through a host interface, with the interface type
+// they need (0: any type). Other item types have no interface.
+var itemInterfaceTypes = map[int]int{
+	ItemTypeZabbixAgent: HostInterfaceAgent,
+	ItemTypeSimpleCheck: 0,
+	ItemTypeExternal:    0,
+	ItemTypeIPMI:        HostInterfaceIPMI,
+	ItemTypeSSH:         0,
+	ItemTypeTelnet:      0,
+	ItemTypeJMX:         HostInterfaceJMX,
+	ItemTypeSNMPTrap:    HostInterfaceSNMP,
+	ItemTypeSNMPAgent:   HostInterfaceSNMP,
+}
 
-const (
-	ItemTypeZabbixAgent = 0
-	ItemTypeTrapper     = 2
-	ItemTypeSNMPAgent   = 20
-)
+// itemTypesWithoutDelay are the item types that are not polled (delay is always 0).
+var itemTypesWithoutDelay = map[int]bool{
+	ItemTypeTrapper:   true,
+	ItemTypeSNMPTrap:  true,
+	ItemTypeDependent: true,
+}
+
+// itemTypesWithTimeout are the item types with a timeout on Zabbix 7.0+. Before 7.0, only HTTP agent and
+// script items have one.
+var itemTypesWithTimeout = map[int]bool{
+	ItemTypeZabbixAgent:       true,
+	ItemTypeSimpleCheck:       true,
+	ItemTypeZabbixAgentActive: true,
+	ItemTypeExternal:          true,
+	ItemTypeDatabaseMonitor:   true,
+	ItemTypeSSH:               true,
+	ItemTypeTelnet:            true,
+	ItemTypeHTTPAgent:         true,
+	ItemTypeSNMPAgent:         true,
+	ItemTypeScript:            true,
+}
 
-func (c *Client) getSNMPInterfaceIDForItem(ctx context.Context, hostID string) (string, error) {
+// itemInterfaceID returns the interface an item of the given type polls through: the main interface of
+// the type it needs (the main agent interface, or else any main interface, for types that accept any).
+// Items on templates have no interface ("0"); item types without interface return "".
+func (c *Client) itemInterfaceID(ctx context.Context, hostID string, itemType int) (string, error) {
+	ifaceType, ok := itemInterfaceTypes[itemType]
+	if !ok {
+		return "", nil
+	}
 	host, err := c.HostGetByID(ctx, hostID)
 	if err != nil {
 		if IsNotFound(err) {
@@ -965,48 +1483,203 @@ func (c *Client) getSNMPInterfaceIDForItem(ctx context.Context, hostID string) (
 		}
 		return "", err
 	}
+	var fallback string
 	for _, iface := range host.Interfaces {
-		if iface.Type == HostInterfaceSNMP {
+		if iface.Main != 1 {
+			continue
+		}
+		if ifaceType == 0 && iface.Type != HostInterfaceAgent {
+			if fallback == "" {
+				fallback = iface.InterfaceID
+			}
+			continue
+		}
+		if ifaceType == 0 || iface.Type == ifaceType {
 			return iface.InterfaceID, nil
 		}
 	}
-	// Host exists but has no SNMP interface - use "0".
-	return "0", nil
+	if fallback != "" {
+		return fallback, nil
+	}
+	return "", fmt.Errorf("host %s has no interface for items of type %d", hostID, itemType)
 }
 
-func (c *Client) ItemCreate(ctx context.Context, req ItemCreateRequest) (string, error) {
-	delayParam := any(req.Delay)
-	if req.Delay == "0" {
-		delayParam = 0
+// itemFieldsParam encodes query fields or headers: a list of {"name", "value"} objects on Zabbix 7.0+,
+// single-entry objects (query fields) or one object (headers) before.
+func itemFieldsParam(fields []ItemField, newFormat, asObject bool) any {
+	if newFormat {
+		if fields == nil {
+			return []ItemField{}
+		}
+		return fields
 	}
-	params := map[string]any{
-		"hostid":     req.HostID,
-		"name":       req.Name,
-		"key_":       req.Key,
-		"type":       req.Type,
-		"value_type": req.ValueType,
-		"delay":      delayParam,
-		"history":   req.History,
-		"trends":    req.Trends,
-		"status":    strconv.Itoa(boolToStatus(req.Enabled)),
+	if asObject {
+		byName := make(map[string]string, len(fields))
+		for _, f := range fields {
+			byName[f.Name] = f.Value
+		}
+		return byName
+	}
+	entries := make([]map[string]string, 0, len(fields))
+	for _, f := range fields {
+		entries = append(entries, map[string]string{f.Name: f.Value})
+	}
+	return entries
+}
+
+// itemParams builds the item.create/update parameters shared by both calls: the common fields and the
+// fields of the item type.
+func (c *Client) itemParams(ctx context.Context, req ItemCreateRequest) (map[string]any, error) {
+	params, err := c.itemSourceParams(ctx, req)
+	if err != nil {
+		return nil, err
 	}
+	params["value_type"] = req.ValueType
+	params["history"] = req.History
+	params["trends"] = req.Trends
 	if req.Units != "" {
 		params["units"] = req.Units
 	}
-	// SNMP agent (type 20 in Zabbix 6.4+): send snmp_oid and interfaceid.
-	// interfaceid is "0" for templates (no interface), or the real SNMP interface ID for hosts.
-	if req.Type == ItemTypeSNMPAgent {
+	tags := req.Tags
+	if tags == nil {
+		tags = []Tag{}
+	}
+	params["tags"] = tags
+	valueMapID := req.ValueMapID
+	if valueMapID == "" {
+		valueMapID = "0"
+	}
+	params["valuemapid"] = valueMapID
+	params["inventory_link"] = req.InventoryLink
+	if req.ValueType == 2 {
+		params["logtimefmt"] = req.LogTimeFmt
+	}
+	return params, nil
+}
+
+// itemSourceParams builds the parameters shared by items and discovery rules: name, key, type, delay,
+// status, preprocessing, interface and the fields of the item type.
+func (c *Client) itemSourceParams(ctx context.Context, req ItemCreateRequest) (map[string]any, error) {
+	params := map[string]any{
+		"name":   req.Name,
+		"key_":   req.Key,
+		"type":   req.Type,
+		"status": strconv.Itoa(BoolToStatus(req.Enabled)),
+	}
+	if !itemTypesWithoutDelay[req.Type] {
+		delayParam := any(req.Delay)
+		if req.Delay == "0" {
+			delayParam = 0
+		}
+		params["delay"] = delayParam
+	}
+	params["description"] = req.Description
+	if req.Type == ItemTypeTrapper || req.Type == ItemTypeHTTPAgent {
+		params["trapper_hosts"] = req.TrapperHosts
+	}
+	preprocessing := req.Preprocessing
+	if preprocessing == nil {
+		preprocessing = []ItemPreprocessing{}
+	}
+	params["preprocessing"] = preprocessing
+
+	interfaceID := req.InterfaceID
+	if interfaceID == "" {
+		var err error
+		interfaceID, err = c.itemInterfaceID(ctx, req.HostID, req.Type)
+		if err != nil {
+			return nil, fmt.Errorf("could not resolve item interface: %w", err)
+		}
+	}
+	if interfaceID != "" {
+		params["interfaceid"] = interfaceID
+	}
+
+	switch req.Type {
+	case ItemTypeSNMPAgent:
+		// SNMP agent (type 20 in Zabbix 6.4+): interfaceid is "0" for templates, the SNMP interface for hosts.
 		snmpOID := strings.TrimSpace(req.SNMPOid)
 		if snmpOID == "" {
-			return "", errors.New("snmp_oid is required for SNMP agent items (type 20)")
+			return nil, errors.New("snmp_oid is required for SNMP agent items (type 20)")
 		}
 		params["snmp_oid"] = snmpOID
-		interfaceID, err := c.getSNMPInterfaceIDForItem(ctx, req.HostID)
+	case ItemTypeCalculated:
+		params["params"] = req.Params
+	case ItemTypeDependent:
+		params["master_itemid"] = req.MasterItemID
+	case ItemTypeScript:
+		parameters := req.Parameters
+		if parameters == nil {
+			parameters = []ItemField{}
+		}
+		params["params"] = req.Params
+		params["parameters"] = parameters
+	case ItemTypeSSH:
+		params["params"] = req.Params
+		params["authtype"] = req.AuthType
+		params["username"] = req.Username
+		params["password"] = req.Password
+		params["publickey"] = req.PublicKey
+		params["privatekey"] = req.PrivateKey
+	case ItemTypeTelnet, ItemTypeDatabaseMonitor:
+		params["params"] = req.Params
+		params["username"] = req.Username
+		params["password"] = req.Password
+	case ItemTypeSimpleCheck, ItemTypeJMX:
+		params["username"] = req.Username
+		params["password"] = req.Password
+		if req.Type == ItemTypeJMX && req.JMXEndpoint != "" {
+			params["jmx_endpoint"] = req.JMXEndpoint
+		}
+	case ItemTypeIPMI:
+		params["ipmi_sensor"] = req.IPMISensor
+	case ItemTypeHTTPAgent:
+		newFormat, err := c.VersionAtLeast(ctx, 7, 0)
 		if err != nil {
-			return "", fmt.Errorf("could not resolve SNMP interface: %w", err)
+			return nil, err
 		}
-		params["interfaceid"] = interfaceID
+		params["url"] = req.URL
+		params["query_fields"] = itemFieldsParam(req.QueryFields, newFormat, false)
+		params["headers"] = itemFieldsParam(req.Headers, newFormat, true)
+		params["posts"] = req.Posts
+		params["post_type"] = req.PostType
+		params["request_method"] = req.RequestMethod
+		params["retrieve_mode"] = req.RetrieveMode
+		params["output_format"] = req.OutputFormat
+		params["status_codes"] = req.StatusCodes
+		params["follow_redirects"] = BoolToInt(req.FollowRedirects)
+		params["verify_peer"] = BoolToInt(req.VerifyPeer)
+		params["verify_host"] = BoolToInt(req.VerifyHost)
+		params["allow_traps"] = BoolToInt(req.AllowTraps)
+		params["http_proxy"] = req.HTTPProxy
+		params["ssl_cert_file"] = req.SSLCertFile
+		params["ssl_key_file"] = req.SSLKeyFile
+		params["ssl_key_password"] = req.SSLKeyPassword
+		params["authtype"] = req.AuthType
+		params["username"] = req.Username
+		params["password"] = req.Password
+	}
+
+	perItemTimeout, err := c.VersionAtLeast(ctx, 7, 0)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case perItemTimeout && itemTypesWithTimeout[req.Type]:
+		// Empty: use the proxy or global timeout of the item type.
+		params["timeout"] = req.Timeout
+	case (req.Type == ItemTypeHTTPAgent || req.Type == ItemTypeScript) && req.Timeout != "":
+		params["timeout"] = req.Timeout
+	}
+	return params, nil
+}
+
+func (c *Client) ItemCreate(ctx context.Context, req ItemCreateRequest) (string, error) {
+	params, err := c.itemParams(ctx, req)
+	if err != nil {
+		return "", err
 	}
+	params["hostid"] = req.HostID
 	var result struct {
 		ItemIDs []string `json:"itemids"`
 	}
@@ -1021,8 +1694,10 @@ func (c *Client) ItemCreate(ctx context.Context, req ItemCreateRequest) (string,
 
 func (c *Client) ItemGetByID(ctx context.Context, id string) (*Item, error) {
 	params := map[string]any{
-		"itemids": []string{id},
-		"output":  []string{"itemid", "hostid", "name", "key_", "type", "value_type", "snmp_oid", "units", "delay", "history", "trends", "delay_flex", "status"},
+		"itemids":             []string{id},
+		"output":              "extend",
+		"selectPreprocessing": "extend",
+		"selectTags":          "extend",
 	}
 	var items []Item
 	if err := c.callAuth(ctx, "item.get", params, &items); err != nil {
@@ -1035,37 +1710,11 @@ func (c *Client) ItemGetByID(ctx context.Context, id string) (*Item, error) {
 }
 
 func (c *Client) ItemUpdate(ctx context.Context, itemID string, req ItemCreateRequest) error {
-	delayParam := any(req.Delay)
-	if req.Delay == "0" {
-		delayParam = 0
-	}
-	params := map[string]any{
-		"itemid":     itemID,
-		"name":       req.Name,
-		"key_":       req.Key,
-		"type":       req.Type,
-		"value_type": req.ValueType,
-		"delay":      delayParam,
-		"history":    req.History,
-		"trends":     req.Trends,
-		"status":    strconv.Itoa(boolToStatus(req.Enabled)),
-	}
-	if req.Units != "" {
-		params["units"] = req.Units
-	}
-	// SNMP agent (type 20 in Zabbix 6.4+): send snmp_oid and interfaceid.
-	if req.Type == ItemTypeSNMPAgent {
-		snmpOID := strings.TrimSpace(req.SNMPOid)
-		if snmpOID == "" {
-			return errors.New("snmp_oid is required for SNMP agent items (type 20)")
-		}
-		params["snmp_oid"] = snmpOID
-		interfaceID, err := c.getSNMPInterfaceIDForItem(ctx, req.HostID)
-		if err != nil {
-			return fmt.Errorf("could not resolve SNMP interface: %w", err)
-		}
-		params["interfaceid"] = interfaceID
+	params, err := c.itemParams(ctx, req)
+	if err != nil {
+		return err
 	}
+	params["itemid"] = itemID
 	var ignored any
 	return c.callAuth(ctx, "item.update", params, &ignored)
 }
@@ -1075,6 +1724,41 @@ func (c *Client) ItemDelete(ctx context.Context, id string) error {
 	return c.callAuth(ctx, "item.delete", []string{id}, &ignored)
 }
 
+// ItemKeyExists reports whether the host or template with the technical name host exists, and whether it
+// has an item with the given key (item keys of expressions reference hosts by technical name).
+func (c *Client) ItemKeyExists(ctx context.Context, host, key string) (hostFound, itemFound bool, err error) {
+	params := map[string]any{
+		"output":          []string{"hostid"},
+		"templated_hosts": true,
+		"filter": map[string]any{
+			"host": []string{host},
+		},
+	}
+	var hosts []struct {
+		HostID string `json:"hostid"`
+	}
+	if err := c.callAuth(ctx, "host.get", params, &hosts); err != nil {
+		return false, false, err
+	}
+	if len(hosts) == 0 {
+		return false, false, nil
+	}
+	params = map[string]any{
+		"output":  []string{"itemid"},
+		"hostids": []string{hosts[0].HostID},
+		"filter": map[string]any{
+			"key_": []string{key},
+		},
+	}
+	var items []struct {
+		ItemID string `json:"itemid"`
+	}
+	if err := c.callAuth(ctx, "item.get", params, &items); err != nil {
+		return true, false, err
+	}
+	return true, len(items) > 0, nil
+}
+
 func StatusToEnabled(status string) bool {
 	value, err := strconv.Atoi(status)
 	if err != nil {
@@ -1083,13 +1767,22 @@ func StatusToEnabled(status string) bool {
 	return value == 0
 }
 
-func boolToStatus(enabled bool) int {
+// BoolToStatus converts an enabled flag to a Zabbix status: 0 is enabled, 1 disabled.
+func BoolToStatus(enabled bool) int {
 	if enabled {
 		return 0
 	}
 	return 1
 }
 
+// BoolToInt converts a flag to the 0/1 integer used by the Zabbix API.
+func BoolToInt(value bool) int {
+	if value {
+		return 1
+	}
+	return 0
+}
+
 // --- Action (trigger notifications, e.g. send email) ---
 
 // flexString unmarshals JSON number or string into string (API may return conditiontype/operator as number).
@@ -1111,95 +1804,178 @@ func (s *flexString) UnmarshalJSON(data []byte) error {
 
 // ActionCondition for 6.4 (top-level) or 7.x (inside filter).
 type ActionCondition struct {
-	ConditionType flexString `json:"conditiontype"`
-	Operator      flexString `json:"operator"`
-	Value         string     `json:"value"`
+	ConditionType string `json:"conditiontype"`
+	Operator      string `json:"operator"`
+	Value         string `json:"value"`
+	Value2        string `json:"value2,omitempty"`    // tag name for "event tag value" conditions
+	FormulaID     string `json:"formulaid,omitempty"` // label used in a custom formula (A, B, ...)
 }
 
-// Action object (trigger-based, eventsource=0). Zabbix 7.x returns filter.conditions, 6.4 returns conditions at root.
-type Action struct {
-	ActionID     string `json:"actionid"`
-	Name         string `json:"name"`
-	EventSource  string `json:"eventsource"` // "0" = trigger
-	EvalType     string `json:"evaltype"`
-	Status       string `json:"status"` // "0"=enabled, "1"=disabled
-	EscPeriod    string `json:"esc_period"`
-	DefShortData string `json:"def_shortdata"`
-	DefLongData  string `json:"def_longdata"`
-	Conditions   []ActionCondition `json:"conditions,omitempty"` // 6.4
-	Filter       *struct {
-		Conditions []ActionCondition `json:"conditions"`
-		EvalType   string            `json:"evaltype"`
-		Formula    string            `json:"formula"`
-	} `json:"filter,omitempty"` // 7.x
-	Operations []struct {
-		OperationType string `json:"operationtype"`
-		OpmessageGrp  []struct {
-			UsrgrpID string `json:"usrgrpid"`
-		} `json:"opmessage_grp,omitempty"`
-		OpmessageUsr []struct {
-			UserID string `json:"userid"`
-		} `json:"opmessage_usr,omitempty"`
-		Opmessage *struct {
-			DefaultMsg string `json:"default_msg"`
-		} `json:"opmessage,omitempty"`
-	} `json:"operations"`
-}
-
-// ActionCreateRequest for trigger-based action (send message to user groups and/or users).
-type ActionCreateRequest struct {
-	Name              string
-	UserGroupIDs      []string // usrgrpid list
-	UserIDs           []string // optional: userid list (e.g. fst-audiovisuel)
-	Subject           string   // def_shortdata
-	Message           string   // def_longdata
-	Enabled           bool
-	EscPeriod         string   // e.g. "1h", "60s"
-	HostGroupIDs      []string // optional: restrict to hosts in these host groups (conditiontype 0)
-	TriggerNameLike   []string // optional: restrict to triggers whose name contains any of these
-}
-
-func (c *Client) ActionCreate(ctx context.Context, req ActionCreateRequest) (string, error) {
-	// API 7.x style: "filter" (not "conditions" at root). conditiontype 0 = Host group, 3 = trigger description.
-	conditions := make([]map[string]any, 0)
-	for _, gid := range req.HostGroupIDs {
-		if gid == "" {
-			continue
-		}
-		conditions = append(conditions, map[string]any{"conditiontype": 0, "operator": 0, "value": gid})
-	}
-	for _, pat := range req.TriggerNameLike {
-		if pat == "" {
-			continue
-		}
-		conditions = append(conditions, map[string]any{"conditiontype": 3, "operator": 2, "value": pat})
-	}
-	if len(conditions) == 0 {
-		conditions = []map[string]any{{"conditiontype": 3, "operator": 2, "value": ""}}
+// UnmarshalJSON accepts conditiontype and operator as numbers or strings.
+func (c *ActionCondition) UnmarshalJSON(data []byte) error {
+	type plain ActionCondition
+	var raw struct {
+		plain
+		ConditionType flexString `json:"conditiontype"`
+		Operator      flexString `json:"operator"`
 	}
-	evaltype := 1   // AND: host in group AND (name like X OR name like Y)
-	if len(conditions) > 1 {
-		evaltype = 2 // OR between conditions (e.g. group Lampe OR group Laser OR trigger name)
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
 	}
-	opmessageGrp := make([]map[string]string, 0, len(req.UserGroupIDs))
-	for _, gid := range req.UserGroupIDs {
-		opmessageGrp = append(opmessageGrp, map[string]string{"usrgrpid": gid})
+	*c = ActionCondition(raw.plain)
+	c.ConditionType = string(raw.ConditionType)
+	c.Operator = string(raw.Operator)
+	return nil
+}
+
+// Action condition evaluation methods (filter "evaltype").
+const (
+	ActionEvalTypeAndOr  = 0
+	ActionEvalTypeAnd    = 1
+	ActionEvalTypeOr     = 2
+	ActionEvalTypeCustom = 3
+)
+
+// Action event sources (eventsource).
+const (
+	ActionEventSourceTrigger          = 0
+	ActionEventSourceDiscovery        = 1
+	ActionEventSourceAutoregistration = 2
+	ActionEventSourceInternal         = 3
+	ActionEventSourceService          = 4
+)
+
+// Action operation types (operationtype). Types 2-10 apply to discovery and autoregistration actions.
+const (
+	ActionOperationMessage        = 0
+	ActionOperationCommand        = 1
+	ActionOperationAddHost        = 2
+	ActionOperationRemoveHost     = 3
+	ActionOperationAddGroup       = 4
+	ActionOperationRemoveGroup    = 5
+	ActionOperationLinkTemplate   = 6
+	ActionOperationUnlinkTemplate = 7
+	ActionOperationEnableHost     = 8
+	ActionOperationDisableHost    = 9
+	ActionOperationInventory      = 10
+	ActionOperationNotifyAll      = 11 // recovery operations: notify all involved
+	ActionOperationNotifyUpdate   = 12 // update operations: notify all involved
+)
+
+// ActionOpmessage is the message of a "send message" operation. DefaultMsg "1" uses the media type
+// message template, "0" sends Subject/Message.
+type ActionOpmessage struct {
+	DefaultMsg  string `json:"default_msg"`
+	Subject     string `json:"subject,omitempty"`
+	Message     string `json:"message,omitempty"`
+	MediaTypeID string `json:"mediatypeid,omitempty"` // "0" or empty = all media types
+}
+
+type ActionOpmessageGrp struct {
+	UsrgrpID string `json:"usrgrpid"`
+}
+
+type ActionOpmessageUsr struct {
+	UserID string `json:"userid"`
+}
+
+// ActionOpcommand references the global script run by a remote command operation.
+type ActionOpcommand struct {
+	ScriptID string `json:"scriptid"`
+}
+
+type ActionOpcommandHst struct {
+	HostID string `json:"hostid"` // "0" = current host
+}
+
+type ActionOpcommandGrp struct {
+	GroupID string `json:"groupid"`
+}
+
+// ActionOpgroup is a host group of an "add to/remove from host group" operation.
+type ActionOpgroup struct {
+	GroupID string `json:"groupid"`
+}
+
+// ActionOptemplate is a template of a "link/unlink template" operation.
+type ActionOptemplate struct {
+	TemplateID string `json:"templateid"`
+}
+
+// ActionOpinventory is the inventory mode set by a "set host inventory mode" operation.
+type ActionOpinventory struct {
+	InventoryMode string `json:"inventory_mode"` // "0"=manual, "1"=automatic
+}
+
+// ActionOperation is an operation, recovery operation or update operation. Escalation fields only apply
+// to operations and are omitted when empty.
+type ActionOperation struct {
+	OperationType string               `json:"operationtype"`
+	EscPeriod     string               `json:"esc_period,omitempty"`
+	EscStepFrom   string               `json:"esc_step_from,omitempty"`
+	EscStepTo     string               `json:"esc_step_to,omitempty"`
+	Opmessage     *ActionOpmessage     `json:"opmessage,omitempty"`
+	OpmessageGrp  []ActionOpmessageGrp `json:"opmessage_grp,omitempty"`
+	OpmessageUsr  []ActionOpmessageUsr `json:"opmessage_usr,omitempty"`
+	Opcommand     *ActionOpcommand     `json:"opcommand,omitempty"`
+	OpcommandHst  []ActionOpcommandHst `json:"opcommand_hst,omitempty"`
+	OpcommandGrp  []ActionOpcommandGrp `json:"opcommand_grp,omitempty"`
+	Opgroup       []ActionOpgroup      `json:"opgroup,omitempty"`
+	Optemplate    []ActionOptemplate   `json:"optemplate,omitempty"`
+	Opinventory   *ActionOpinventory   `json:"opinventory,omitempty"`
+}
+
+// Action object. Zabbix 7.x returns filter.conditions, 6.4 returns conditions at root.
+type Action struct {
+	ActionID     string `json:"actionid"`
+	Name         string `json:"name"`
+	EventSource  string `json:"eventsource"` // ActionEventSource*
+	EvalType     string `json:"evaltype"`
+	Status       string `json:"status"` // "0"=enabled, "1"=disabled
+	EscPeriod    string `json:"esc_period"`
+	Conditions   []ActionCondition `json:"conditions,omitempty"` // 6.4
+	Filter       *struct {
+		Conditions []ActionCondition `json:"conditions"`
+		EvalType   string            `json:"evaltype"`
+		Formula    string            `json:"formula"`
+	} `json:"filter,omitempty"` // 7.x
+	Operations         []ActionOperation `json:"operations"`
+	RecoveryOperations []ActionOperation `json:"recovery_operations"`
+	UpdateOperations   []ActionOperation `json:"update_operations"`
+}
+
+// ActionCreateRequest for an action: conditions, (escalated) operations plus recovery and update operations.
+type ActionCreateRequest struct {
+	Name               string
+	EventSource        int // ActionEventSource*; cannot be changed by action.update
+	Enabled            bool
+	EscPeriod          string // e.g. "1h", "60s"
+	EvalType           int    // ActionEvalType*
+	Formula            string // with ActionEvalTypeCustom, e.g. "A and (B or C)"
+	Conditions         []ActionCondition
+	Operations         []ActionOperation
+	RecoveryOperations []ActionOperation
+	UpdateOperations   []ActionOperation
+}
+
+// actionParams builds the fields shared by action.create and action.update.
+func actionParams(req ActionCreateRequest) map[string]any {
+	conditions := req.Conditions
+	if conditions == nil {
+		conditions = []ActionCondition{}
 	}
-	opmessageUsr := make([]map[string]string, 0, len(req.UserIDs))
-	for _, uid := range req.UserIDs {
-		if uid != "" {
-			opmessageUsr = append(opmessageUsr, map[string]string{"userid": uid})
-		}
+	operations := req.Operations
+	if operations == nil {
+		operations = []ActionOperation{}
 	}
-	op := map[string]any{
-		"operationtype": "0",
-		"opmessage_grp": opmessageGrp,
-		"opmessage":     map[string]string{"default_msg": "1"},
+	recoveryOperations := req.RecoveryOperations
+	if recoveryOperations == nil {
+		recoveryOperations = []ActionOperation{}
 	}
-	if len(opmessageUsr) > 0 {
-		op["opmessage_usr"] = opmessageUsr
+	updateOperations := req.UpdateOperations
+	if updateOperations == nil {
+		updateOperations = []ActionOperation{}
 	}
-	operations := []map[string]any{op}
 	status := "1"
 	if req.Enabled {
 		status = "0"
@@ -1208,16 +1984,34 @@ func (c *Client) ActionCreate(ctx context.Context, req ActionCreateRequest) (str
 	if escPeriod == "" {
 		escPeriod = "1h"
 	}
-	filter := map[string]any{"conditions": conditions, "evaltype": evaltype}
+	// API 7.x style: "filter" (not "conditions" at root).
+	filter := map[string]any{"conditions": conditions, "evaltype": req.EvalType}
+	if req.EvalType == ActionEvalTypeCustom {
+		filter["formula"] = req.Formula
+	}
 	params := map[string]any{
-		"name":        req.Name,
-		"eventsource": "0",
-		"filter":      filter,
-		"status":      status,
-		"esc_period":  escPeriod,
-		"operations":  operations,
-	}
-	// Note: this API version rejects def_shortdata/def_longdata and opmessage subject/message; message uses media type default.
+		"name":       req.Name,
+		"filter":     filter,
+		"status":     status,
+		"operations": operations,
+	}
+	// Escalations and recovery operations exist for trigger, internal and service events; update
+	// operations for trigger and service events. Zabbix rejects them on other event sources.
+	switch req.EventSource {
+	case ActionEventSourceTrigger, ActionEventSourceService:
+		params["esc_period"] = escPeriod
+		params["recovery_operations"] = recoveryOperations
+		params["update_operations"] = updateOperations
+	case ActionEventSourceInternal:
+		params["esc_period"] = escPeriod
+		params["recovery_operations"] = recoveryOperations
+	}
+	return params
+}
+
+func (c *Client) ActionCreate(ctx context.Context, req ActionCreateRequest) (string, error) {
+	params := actionParams(req)
+	params["eventsource"] = strconv.Itoa(req.EventSource)
 	var result struct {
 		ActionIDs []string `json:"actionids"`
 	}
@@ -1232,11 +2026,13 @@ func (c *Client) ActionCreate(ctx context.Context, req ActionCreateRequest) (str
 
 func (c *Client) ActionGetByID(ctx context.Context, id string) (*Action, error) {
 	params := map[string]any{
-		"actionids":        []string{id},
-		"output":           "extend",
-		"selectFilter":     "extend",
-		"selectConditions": "extend",
-		"selectOperations": "extend",
+		"actionids":                []string{id},
+		"output":                   "extend",
+		"selectFilter":             "extend",
+		"selectConditions":         "extend",
+		"selectOperations":         "extend",
+		"selectRecoveryOperations": "extend",
+		"selectUpdateOperations":   "extend",
 	}
 	var actions []Action
 	if err := c.callAuth(ctx, "action.get", params, &actions); err != nil {
@@ -1249,62 +2045,8 @@ func (c *Client) ActionGetByID(ctx context.Context, id string) (*Action, error)
 }
 
 func (c *Client) ActionUpdate(ctx context.Context, id string, req ActionCreateRequest) error {
-	conditions := make([]map[string]any, 0)
-	for _, gid := range req.HostGroupIDs {
-		if gid == "" {
-			continue
-		}
-		conditions = append(conditions, map[string]any{"conditiontype": 0, "operator": 0, "value": gid})
-	}
-	for _, pat := range req.TriggerNameLike {
-		if pat == "" {
-			continue
-		}
-		conditions = append(conditions, map[string]any{"conditiontype": 3, "operator": 2, "value": pat})
-	}
-	if len(conditions) == 0 {
-		conditions = []map[string]any{{"conditiontype": 3, "operator": 2, "value": ""}}
-	}
-	evaltype := 1
-	if len(conditions) > 1 {
-		evaltype = 2
-	}
-	opmessageGrp := make([]map[string]string, 0, len(req.UserGroupIDs))
-	for _, gid := range req.UserGroupIDs {
-		opmessageGrp = append(opmessageGrp, map[string]string{"usrgrpid": gid})
-	}
-	opmessageUsr := make([]map[string]string, 0, len(req.UserIDs))
-	for _, uid := range req.UserIDs {
-		if uid != "" {
-			opmessageUsr = append(opmessageUsr, map[string]string{"userid": uid})
-		}
-	}
-	op := map[string]any{
-		"operationtype": "0",
-		"opmessage_grp": opmessageGrp,
-		"opmessage":     map[string]string{"default_msg": "1"},
-	}
-	if len(opmessageUsr) > 0 {
-		op["opmessage_usr"] = opmessageUsr
-	}
-	operations := []map[string]any{op}
-	status := "1"
-	if req.Enabled {
-		status = "0"
-	}
-	escPeriod := req.EscPeriod
-	if escPeriod == "" {
-		escPeriod = "1h"
-	}
-	filter := map[string]any{"conditions": conditions, "evaltype": evaltype}
-	params := map[string]any{
-		"actionid":   id,
-		"name":       req.Name,
-		"filter":     filter,
-		"status":     status,
-		"esc_period": escPeriod,
-		"operations": operations,
-	}
+	params := actionParams(req)
+	params["actionid"] = id
 	var ignored any
 	return c.callAuth(ctx, "action.update", params, &ignored)
 }
@@ -1316,20 +2058,58 @@ func (c *Client) ActionDelete(ctx context.Context, id string) error {
 
 // --- User group (for action recipients) ---
 
+// UserGroupRight grants a permission on a host group or template group.
+type UserGroupRight struct {
+	ID         string `json:"id"`         // host group or template group id
+	Permission string `json:"permission"` // "0"=Deny, "2"=Read, "3"=Read-write
+}
+
+// UserGroupTagFilter restricts problem visibility in a host group to problems with the given tag (and value).
+type UserGroupTagFilter struct {
+	GroupID string `json:"groupid"`
+	Tag     string `json:"tag"`
+	Value   string `json:"value"`
+}
+
 type UserGroup struct {
-	UsrgrpID string `json:"usrgrpid"`
-	Name     string `json:"name"`
-	Rights   []struct {
-		ID         string `json:"id"`         // host group id
-		Permission string `json:"permission"` // "2"=Read, "3"=Read-write
-	} `json:"rights,omitempty"`
+	UsrgrpID    string `json:"usrgrpid"`
+	Name        string `json:"name"`
+	GuiAccess   string `json:"gui_access"`   // "0"=system default, "1"=internal, "2"=LDAP, "3"=disabled
+	UsersStatus string `json:"users_status"` // "0"=enabled, "1"=disabled
+	DebugMode   string `json:"debug_mode"`   // "0"=disabled, "1"=enabled
+	// Rights is returned by Zabbix < 6.2; 6.2+ splits it into HostGroupRights and TemplateGroupRights.
+	Rights              []UserGroupRight     `json:"rights,omitempty"`
+	HostGroupRights     []UserGroupRight     `json:"hostgroup_rights,omitempty"`
+	TemplateGroupRights []UserGroupRight     `json:"templategroup_rights,omitempty"`
+	TagFilters          []UserGroupTagFilter `json:"tag_filters,omitempty"`
+}
+
+// UserGroupCreateRequest for usergroup.create / usergroup.update.
+type UserGroupCreateRequest struct {
+	Name                string
+	HostGroupRights     []UserGroupRight
+	TemplateGroupRights []UserGroupRight // Zabbix 6.2+
+	TagFilters          []UserGroupTagFilter
+	GuiAccess           int
+	UsersStatus         int
+	DebugMode           int
 }
 
 func (c *Client) UserGroupGetByID(ctx context.Context, id string) (*UserGroup, error) {
 	params := map[string]any{
-		"usrgrpids":     []string{id},
-		"output":        []string{"usrgrpid", "name"},
-		"selectRights":  "extend",
+		"usrgrpids":        []string{id},
+		"output":           []string{"usrgrpid", "name", "gui_access", "users_status", "debug_mode"},
+		"selectTagFilters": "extend",
+	}
+	split, err := c.VersionAtLeast(ctx, 6, 2)
+	if err != nil {
+		return nil, err
+	}
+	if split {
+		params["selectHostGroupRights"] = "extend"
+		params["selectTemplateGroupRights"] = "extend"
+	} else {
+		params["selectRights"] = "extend"
 	}
 	var groups []UserGroup
 	if err := c.callAuth(ctx, "usergroup.get", params, &groups); err != nil {
@@ -1338,7 +2118,11 @@ func (c *Client) UserGroupGetByID(ctx context.Context, id string) (*UserGroup, e
 	if len(groups) == 0 {
 		return nil, ErrNotFound
 	}
-	return &groups[0], nil
+	grp := &groups[0]
+	if !split {
+		grp.HostGroupRights = grp.Rights
+	}
+	return grp, nil
 }
 
 // UserGroupIDsByNames returns usergroup IDs for the given names (e.g. "Zabbix administrators").
@@ -1364,21 +2148,55 @@ func (c *Client) UserGroupIDsByNames(ctx context.Context, names []string) ([]str
 	return out, nil
 }
 
-// Permission for host group access: "2" = Read, "3" = Read-write
-const UsergroupPermissionRead = "2"
+// Permission for host group / template group access.
+const (
+	UsergroupPermissionDeny      = "0"
+	UsergroupPermissionRead      = "2"
+	UsergroupPermissionReadWrite = "3"
+)
 
-func (c *Client) UserGroupCreate(ctx context.Context, name string, hostGroupReadIDs []string) (string, error) {
-	params := map[string]any{"name": name}
-	if len(hostGroupReadIDs) > 0 {
-		rights := make([]map[string]string, 0, len(hostGroupReadIDs))
-		for _, gid := range hostGroupReadIDs {
-			if gid != "" {
-				rights = append(rights, map[string]string{"id": gid, "permission": UsergroupPermissionRead})
-			}
-		}
-		if len(rights) > 0 {
-			params["rights"] = rights
+// userGroupParams builds the common usergroup.create/update payload. Rights are always sent so that
+// removing a block in the configuration revokes the permission.
+func (c *Client) userGroupParams(ctx context.Context, req UserGroupCreateRequest) (map[string]any, error) {
+	hostGroupRights := req.HostGroupRights
+	if hostGroupRights == nil {
+		hostGroupRights = []UserGroupRight{}
+	}
+	templateGroupRights := req.TemplateGroupRights
+	if templateGroupRights == nil {
+		templateGroupRights = []UserGroupRight{}
+	}
+	tagFilters := req.TagFilters
+	if tagFilters == nil {
+		tagFilters = []UserGroupTagFilter{}
+	}
+	params := map[string]any{
+		"name":         req.Name,
+		"gui_access":   strconv.Itoa(req.GuiAccess),
+		"users_status": strconv.Itoa(req.UsersStatus),
+		"debug_mode":   strconv.Itoa(req.DebugMode),
+		"tag_filters":  tagFilters,
+	}
+	split, err := c.VersionAtLeast(ctx, 6, 2)
+	if err != nil {
+		return nil, err
+	}
+	if split {
+		params["hostgroup_rights"] = hostGroupRights
+		params["templategroup_rights"] = templateGroupRights
+	} else {
+		if len(templateGroupRights) > 0 {
+			return nil, errors.New("template group rights require Zabbix 6.2 or later")
 		}
+		params["rights"] = hostGroupRights
+	}
+	return params, nil
+}
+
+func (c *Client) UserGroupCreate(ctx context.Context, req UserGroupCreateRequest) (string, error) {
+	params, err := c.userGroupParams(ctx, req)
+	if err != nil {
+		return "", err
 	}
 	var result struct {
 		UsrgrpIDs []string `json:"usrgrpids"`
@@ -1392,18 +2210,12 @@ func (c *Client) UserGroupCreate(ctx context.Context, name string, hostGroupRead
 	return result.UsrgrpIDs[0], nil
 }
 
-// UserGroupUpdate updates the user group. Pass nil for hostGroupReadIDs to leave rights unchanged.
-func (c *Client) UserGroupUpdate(ctx context.Context, id, name string, hostGroupReadIDs []string) error {
-	params := map[string]any{"usrgrpid": id, "name": name}
-	if hostGroupReadIDs != nil {
-		rights := make([]map[string]string, 0, len(hostGroupReadIDs))
-		for _, gid := range hostGroupReadIDs {
-			if gid != "" {
-				rights = append(rights, map[string]string{"id": gid, "permission": UsergroupPermissionRead})
-			}
-		}
-		params["rights"] = rights
+func (c *Client) UserGroupUpdate(ctx context.Context, id string, req UserGroupCreateRequest) error {
+	params, err := c.userGroupParams(ctx, req)
+	if err != nil {
+		return err
 	}
+	params["usrgrpid"] = id
 	var ignored any
 	return c.callAuth(ctx, "usergroup.update", params, &ignored)
 }
@@ -1413,14 +2225,123 @@ func (c *Client) UserGroupDelete(ctx context.Context, id string) error {
 	return c.callAuth(ctx, "usergroup.delete", []string{id}, &ignored)
 }
 
+// Media type kinds (mediatype "type").
+const (
+	MediaTypeEmail   = 0
+	MediaTypeScript  = 1
+	MediaTypeSMS     = 2
+	MediaTypeWebhook = 4
+)
+
+// UserMedia is a user's notification media (e.g. email address, Slack user, phone number).
+type UserMedia struct {
+	MediaID     string `json:"mediaid,omitempty"`
+	MediaTypeID string `json:"mediatypeid"`
+	SendTo      string `json:"sendto"`
+	Active      string `json:"active"`   // "0"=enabled, "1"=disabled
+	Severity    string `json:"severity"` // bitmask of trigger severities 0..5 (63 = all)
+	Period      string `json:"period"`   // e.g. "1-5,09:00-18:00"
+}
+
+// UnmarshalJSON accepts sendto as a string or, for email media, an array of addresses.
+func (m *UserMedia) UnmarshalJSON(data []byte) error {
+	type plain UserMedia
+	var raw struct {
+		plain
+		SendTo sendToString `json:"sendto"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	*m = UserMedia(raw.plain)
+	m.SendTo = string(raw.SendTo)
+	return nil
+}
+
 // UserCreateRequest for creating a Zabbix user (e.g. for notifications).
 type UserCreateRequest struct {
 	Username   string
 	Name       string // display name
 	Password   string
 	UserGrpIDs []string // must contain at least one group
-	RoleID     string   // "1"=User, "2"=Admin, "3"=Super admin
-	Email      string   // if set, add Email media (mediatypeid 1) with this sendto
+	RoleID     string   // roleid; built-in "1"=User role, "2"=Admin role, "3"=Super admin role
+	Medias     []UserMedia
+
+	// Profile settings.
+	Surname     string
+	Lang        string // e.g. "en_US", "default"
+	Timezone    string // e.g. "Europe/Paris", "default"
+	Theme       string // "default", "blue-theme", "dark-theme", ...
+	Autologin   int    // 0/1
+	Autologout  string // e.g. "15m", "0" (disabled)
+	Refresh     string // e.g. "30s"
+	RowsPerPage int
+}
+
+// userProfileParams adds the profile settings shared by user.create and user.update.
+func userProfileParams(params map[string]any, req UserCreateRequest) {
+	params["surname"] = req.Surname
+	params["autologin"] = strconv.Itoa(req.Autologin)
+	if req.Lang != "" {
+		params["lang"] = req.Lang
+	}
+	if req.Timezone != "" {
+		params["timezone"] = req.Timezone
+	}
+	if req.Theme != "" {
+		params["theme"] = req.Theme
+	}
+	if req.Autologout != "" {
+		params["autologout"] = req.Autologout
+	}
+	if req.Refresh != "" {
+		params["refresh"] = req.Refresh
+	}
+	if req.RowsPerPage > 0 {
+		params["rows_per_page"] = strconv.Itoa(req.RowsPerPage)
+	}
+}
+
+// userMediasParam builds the "medias" payload. Email media types expect sendto as an array,
+// every other media type as a plain string, so the referenced media types are looked up first.
+func (c *Client) userMediasParam(ctx context.Context, medias []UserMedia) ([]map[string]any, error) {
+	out := make([]map[string]any, 0, len(medias))
+	if len(medias) == 0 {
+		return out, nil
+	}
+	ids := make([]string, 0, len(medias))
+	for _, m := range medias {
+		ids = append(ids, m.MediaTypeID)
+	}
+	params := map[string]any{
+		"mediatypeids": ids,
+		"output":       []string{"mediatypeid", "type"},
+	}
+	var mediaTypes []struct {
+		MediaTypeID string     `json:"mediatypeid"`
+		Type        flexString `json:"type"`
+	}
+	if err := c.callAuth(ctx, "mediatype.get", params, &mediaTypes); err != nil {
+		return nil, err
+	}
+	isEmail := make(map[string]bool, len(mediaTypes))
+	for _, mt := range mediaTypes {
+		isEmail[mt.MediaTypeID] = string(mt.Type) == strconv.Itoa(MediaTypeEmail)
+	}
+	for _, m := range medias {
+		var sendTo any = m.SendTo
+		if isEmail[m.MediaTypeID] {
+			sendTo = []string{m.SendTo}
+		}
+		out = append(out, map[string]any{
+			"mediatypeid": m.MediaTypeID,
+			"sendto":      sendTo,
+			"active":      m.Active,
+			"severity":    m.Severity,
+			"period":      m.Period,
+		})
+	}
+	return out, nil
 }
 
 func (c *Client) UserCreate(ctx context.Context, req UserCreateRequest) (string, error) {
@@ -1440,16 +2361,13 @@ func (c *Client) UserCreate(ctx context.Context, req UserCreateRequest) (string,
 	if req.Password != "" {
 		params["passwd"] = req.Password
 	}
-	if req.Email != "" {
-		params["medias"] = []map[string]any{
-			{
-				"mediatypeid": "1",
-				"sendto":      []string{req.Email},
-				"active":      1,
-				"severity":    63,
-				"period":      "1-7,00:00-24:00",
-			},
+	userProfileParams(params, req)
+	if len(req.Medias) > 0 {
+		medias, err := c.userMediasParam(ctx, req.Medias)
+		if err != nil {
+			return "", fmt.Errorf("mediatype.get: %w", err)
 		}
+		params["medias"] = medias
 	}
 	var result struct {
 		UserIDs []string `json:"userids"`
@@ -1465,10 +2383,10 @@ func (c *Client) UserCreate(ctx context.Context, req UserCreateRequest) (string,
 
 func (c *Client) UserGetByID(ctx context.Context, id string) (*User, error) {
 	params := map[string]any{
-		"userids":        []string{id},
-		"output":         "extend",
-		"selectMedias":   "extend",
-		"selectUsrgrps":  "extend",
+		"userids":       []string{id},
+		"output":        "extend",
+		"selectMedias":  "extend",
+		"selectUsrgrps": "extend",
 	}
 	var users []User
 	if err := c.callAuth(ctx, "user.get", params, &users); err != nil {
@@ -1499,19 +2417,25 @@ func (s *sendToString) UnmarshalJSON(data []byte) error {
 }
 
 type User struct {
-	UserID   string `json:"userid"`
-	Username string `json:"username"`
-	Name     string `json:"name"`
-	RoleID   string `json:"roleid"`
-	Medias   []struct {
-		MediaTypeID string       `json:"mediatypeid"`
-		SendTo      sendToString `json:"sendto"`
-	} `json:"medias,omitempty"`
-	Usrgrps []struct {
+	UserID      string      `json:"userid"`
+	Username    string      `json:"username"`
+	Name        string      `json:"name"`
+	Surname     string      `json:"surname"`
+	RoleID      string      `json:"roleid"`
+	Lang        string      `json:"lang"`
+	Timezone    string      `json:"timezone"`
+	Theme       string      `json:"theme"`
+	Autologin   string      `json:"autologin"`
+	Autologout  string      `json:"autologout"`
+	Refresh     string      `json:"refresh"`
+	RowsPerPage string      `json:"rows_per_page"`
+	Medias      []UserMedia `json:"medias,omitempty"`
+	Usrgrps     []struct {
 		UsrgrpID string `json:"usrgrpid"`
 	} `json:"usrgrps,omitempty"`
 }
 
+// UserUpdate updates the user. Medias are always sent, so an empty list removes all media.
 func (c *Client) UserUpdate(ctx context.Context, userID string, req UserCreateRequest) error {
 	usrgrps := make([]map[string]string, 0, len(req.UserGrpIDs))
 	for _, gid := range req.UserGrpIDs {
@@ -1530,17 +2454,12 @@ func (c *Client) UserUpdate(ctx context.Context, userID string, req UserCreateRe
 	if req.Password != "" {
 		params["passwd"] = req.Password
 	}
-	if req.Email != "" {
-		params["medias"] = []map[string]any{
-			{
-				"mediatypeid": "1",
-				"sendto":      []string{req.Email},
-				"active":      1,
-				"severity":    63,
-				"period":      "1-7,00:00-24:00",
-			},
-		}
+	userProfileParams(params, req)
+	medias, err := c.userMediasParam(ctx, req.Medias)
+	if err != nil {
+		return fmt.Errorf("mediatype.get: %w", err)
 	}
+	params["medias"] = medias
 	var ignored any
 	return c.callAuth(ctx, "user.update", params, &ignored)
 }
@@ -1550,3 +2469,2020 @@ func (c *Client) UserDelete(ctx context.Context, id string) error {
 	return c.callAuth(ctx, "user.delete", []string{id}, &ignored)
 }
 
+// --- User role ---
+
+// Role types (user type granted by the role).
+const (
+	RoleTypeUser       = 1
+	RoleTypeAdmin      = 2
+	RoleTypeSuperAdmin = 3
+)
+
+// RoleRule enables ("1") or disables ("0") access to a UI element or action.
+type RoleRule struct {
+	Name   string `json:"name"`
+	Status string `json:"status"`
+}
+
+// RoleModuleRule enables ("1") or disables ("0") access to a frontend module.
+type RoleModuleRule struct {
+	ModuleID string `json:"moduleid"`
+	Status   string `json:"status"`
+}
+
+// RoleRules mirrors the role "rules" object. Slices must be non-nil on create/update: rules left out are reset by Zabbix.
+type RoleRules struct {
+	UI                   []RoleRule       `json:"ui"`
+	UIDefaultAccess      string           `json:"ui.default_access"`
+	Modules              []RoleModuleRule `json:"modules"`
+	ModulesDefaultAccess string           `json:"modules.default_access"`
+	APIAccess            string           `json:"api.access"`
+	APIMode              string           `json:"api.mode"` // "0"=deny list, "1"=allow list
+	API                  []string         `json:"api"`
+	Actions              []RoleRule       `json:"actions"`
+	ActionsDefaultAccess string           `json:"actions.default_access"`
+}
+
+type Role struct {
+	RoleID   string    `json:"roleid"`
+	Name     string    `json:"name"`
+	Type     string    `json:"type"`
+	ReadOnly string    `json:"readonly"`
+	Rules    RoleRules `json:"rules"`
+}
+
+type RoleCreateRequest struct {
+	Name  string
+	Type  int
+	Rules RoleRules
+}
+
+func (c *Client) RoleCreate(ctx context.Context, req RoleCreateRequest) (string, error) {
+	params := map[string]any{
+		"name":  req.Name,
+		"type":  req.Type,
+		"rules": req.Rules,
+	}
+	var result struct {
+		RoleIDs []string `json:"roleids"`
+	}
+	if err := c.callAuth(ctx, "role.create", params, &result); err != nil {
+		return "", err
+	}
+	if len(result.RoleIDs) == 0 {
+		return "", errors.New("role.create returned no roleid")
+	}
+	return result.RoleIDs[0], nil
+}
+
+func (c *Client) RoleGetByID(ctx context.Context, id string) (*Role, error) {
+	params := map[string]any{
+		"roleids":     []string{id},
+		"output":      "extend",
+		"selectRules": "extend",
+	}
+	var roles []Role
+	if err := c.callAuth(ctx, "role.get", params, &roles); err != nil {
+		return nil, err
+	}
+	if len(roles) == 0 {
+		return nil, ErrNotFound
+	}
+	return &roles[0], nil
+}
+
+// RoleGetByName returns the role with the exact given name (e.g. "Admin role").
+func (c *Client) RoleGetByName(ctx context.Context, name string) (*Role, error) {
+	params := map[string]any{
+		"output": "extend",
+		"filter": map[string]any{"name": []string{name}},
+	}
+	var roles []Role
+	if err := c.callAuth(ctx, "role.get", params, &roles); err != nil {
+		return nil, err
+	}
+	if len(roles) == 0 {
+		return nil, fmt.Errorf("role not found: %s", name)
+	}
+	if len(roles) > 1 {
+		return nil, fmt.Errorf("ambiguous role: %s", name)
+	}
+	return &roles[0], nil
+}
+
+func (c *Client) RoleUpdate(ctx context.Context, id string, req RoleCreateRequest) error {
+	params := map[string]any{
+		"roleid": id,
+		"name":   req.Name,
+		"type":   req.Type,
+		"rules":  req.Rules,
+	}
+	var ignored any
+	return c.callAuth(ctx, "role.update", params, &ignored)
+}
+
+func (c *Client) RoleDelete(ctx context.Context, id string) error {
+	var ignored any
+	return c.callAuth(ctx, "role.delete", []string{id}, &ignored)
+}
+
+// --- Media type ---
+
+// MediaTypeParameter is a webhook parameter (name/value) or, on Zabbix 6.4+, a script parameter (sortorder/value).
+type MediaTypeParameter struct {
+	Name      string   `json:"name,omitempty"`
+	SortOrder *FlexInt `json:"sortorder,omitempty"`
+	Value     string   `json:"value"`
+}
+
+// MediaTypeMessageTemplate is the default message for an event source/recovery combination.
+type MediaTypeMessageTemplate struct {
+	EventSource string `json:"eventsource"` // 0=triggers, 1=discovery, 2=autoregistration, 3=internal, 4=services
+	Recovery    string `json:"recovery"`    // 0=problem, 1=recovery, 2=update
+	Subject     string `json:"subject"`
+	Message     string `json:"message"`
+}
+
+// MediaType object. Numeric fields are kept as strings, as returned by mediatype.get.
+type MediaType struct {
+	MediaTypeID     string `json:"mediatypeid,omitempty"`
+	Name            string `json:"name"`
+	Type            string `json:"type"`   // 0=email, 1=script, 2=SMS, 4=webhook
+	Status          string `json:"status"` // 0=enabled, 1=disabled
+	Description     string `json:"description"`
+	MaxSessions     string `json:"maxsessions"`
+	MaxAttempts     string `json:"maxattempts"`
+	AttemptInterval string `json:"attempt_interval"`
+
+	// Email
+	SMTPServer         string `json:"smtp_server"`
+	SMTPPort           string `json:"smtp_port"`
+	SMTPHelo           string `json:"smtp_helo"`
+	SMTPEmail          string `json:"smtp_email"`
+	SMTPSecurity       string `json:"smtp_security"` // 0=none, 1=STARTTLS, 2=SSL/TLS
+	SMTPVerifyPeer     string `json:"smtp_verify_peer"`
+	SMTPVerifyHost     string `json:"smtp_verify_host"`
+	SMTPAuthentication string `json:"smtp_authentication"` // 0=none, 1=username/password
+	Username           string `json:"username"`
+	Passwd             string `json:"passwd,omitempty"` // never returned by the API
+	ContentType        string `json:"content_type"`     // 0=plain text, 1=HTML
+
+	// Script
+	ExecPath   string `json:"exec_path"`
+	ExecParams string `json:"exec_params,omitempty"` // before 6.4: one parameter per line
+
+	// SMS
+	GSMModem string `json:"gsm_modem"`
+
+	// Webhook
+	Script        string `json:"script"`
+	Timeout       string `json:"timeout"`
+	ProcessTags   string `json:"process_tags"`
+	ShowEventMenu string `json:"show_event_menu"`
+	EventMenuURL  string `json:"event_menu_url"`
+	EventMenuName string `json:"event_menu_name"`
+
+	Parameters       []MediaTypeParameter       `json:"parameters"`
+	MessageTemplates []MediaTypeMessageTemplate `json:"message_templates"`
+}
+
+// mediaTypeParams converts a MediaType into a mediatype.create/update payload. Only the fields of the
+// media type kind are sent: Zabbix rejects e.g. smtp_server on a webhook. Script parameters are sent as
+// "parameters" on Zabbix 6.4+ and as newline-separated "exec_params" before.
+func (c *Client) mediaTypeParams(ctx context.Context, mt MediaType) (map[string]any, error) {
+	params := map[string]any{
+		"name":             mt.Name,
+		"type":             mt.Type,
+		"status":           mt.Status,
+		"description":      mt.Description,
+		"maxsessions":      mt.MaxSessions,
+		"maxattempts":      mt.MaxAttempts,
+		"attempt_interval": mt.AttemptInterval,
+	}
+	templates := mt.MessageTemplates
+	if templates == nil {
+		templates = []MediaTypeMessageTemplate{}
+	}
+	params["message_templates"] = templates
+	parameters := mt.Parameters
+	if parameters == nil {
+		parameters = []MediaTypeParameter{}
+	}
+
+	switch mt.Type {
+	case strconv.Itoa(MediaTypeEmail):
+		params["smtp_server"] = mt.SMTPServer
+		params["smtp_port"] = mt.SMTPPort
+		params["smtp_helo"] = mt.SMTPHelo
+		params["smtp_email"] = mt.SMTPEmail
+		params["smtp_security"] = mt.SMTPSecurity
+		params["smtp_authentication"] = mt.SMTPAuthentication
+		params["content_type"] = mt.ContentType
+		if mt.SMTPSecurity != "0" {
+			params["smtp_verify_peer"] = mt.SMTPVerifyPeer
+			params["smtp_verify_host"] = mt.SMTPVerifyHost
+		}
+		if mt.SMTPAuthentication == "1" {
+			params["username"] = mt.Username
+			if mt.Passwd != "" {
+				params["passwd"] = mt.Passwd
+			}
+		}
+	case strconv.Itoa(MediaTypeScript):
+		params["exec_path"] = mt.ExecPath
+		scriptParams, err := c.VersionAtLeast(ctx, 6, 4)
+		if err != nil {
+			return nil, err
+		}
+		if scriptParams {
+			params["parameters"] = parameters
+		} else {
+			lines := make([]string, 0, len(parameters))
+			for _, p := range parameters {
+				lines = append(lines, p.Value+"\n")
+			}
+			params["exec_params"] = strings.Join(lines, "")
+		}
+	case strconv.Itoa(MediaTypeSMS):
+		params["gsm_modem"] = mt.GSMModem
+	case strconv.Itoa(MediaTypeWebhook):
+		params["script"] = mt.Script
+		params["timeout"] = mt.Timeout
+		params["process_tags"] = mt.ProcessTags
+		params["show_event_menu"] = mt.ShowEventMenu
+		params["parameters"] = parameters
+		if mt.ShowEventMenu == "1" {
+			params["event_menu_url"] = mt.EventMenuURL
+			params["event_menu_name"] = mt.EventMenuName
+		}
+	}
+	return params, nil
+}
+
+func (c *Client) MediaTypeCreate(ctx context.Context, mt MediaType) (string, error) {
+	params, err := c.mediaTypeParams(ctx, mt)
+	if err != nil {
+		return "", err
+	}
+	var result struct {
+		MediaTypeIDs []string `json:"mediatypeids"`
+	}
+	if err := c.callAuth(ctx, "mediatype.create", params, &result); err != nil {
+		return "", err
+	}
+	if len(result.MediaTypeIDs) == 0 {
+		return "", errors.New("mediatype.create returned no mediatypeid")
+	}
+	return result.MediaTypeIDs[0], nil
+}
+
+func (c *Client) MediaTypeGetByID(ctx context.Context, id string) (*MediaType, error) {
+	params := map[string]any{
+		"mediatypeids":           []string{id},
+		"output":                 "extend",
+		"selectMessageTemplates": "extend",
+	}
+	var mediaTypes []MediaType
+	if err := c.callAuth(ctx, "mediatype.get", params, &mediaTypes); err != nil {
+		return nil, err
+	}
+	if len(mediaTypes) == 0 {
+		return nil, ErrNotFound
+	}
+	mt := &mediaTypes[0]
+	if mt.Type == strconv.Itoa(MediaTypeScript) && len(mt.Parameters) == 0 && mt.ExecParams != "" {
+		for i, line := range strings.Split(strings.TrimSuffix(mt.ExecParams, "\n"), "\n") {
+			order := FlexInt(i)
+			mt.Parameters = append(mt.Parameters, MediaTypeParameter{SortOrder: &order, Value: line})
+		}
+	}
+	return mt, nil
+}
+
+// MediaTypeGetByName returns the media type with the exact given name (e.g. "Email", "Slack").
+func (c *Client) MediaTypeGetByName(ctx context.Context, name string) (*MediaType, error) {
+	params := map[string]any{
+		"output": []string{"mediatypeid", "name", "type", "status"},
+		"filter": map[string]any{"name": []string{name}},
+	}
+	var mediaTypes []MediaType
+	if err := c.callAuth(ctx, "mediatype.get", params, &mediaTypes); err != nil {
+		return nil, err
+	}
+	if len(mediaTypes) == 0 {
+		return nil, fmt.Errorf("media type not found: %s", name)
+	}
+	if len(mediaTypes) > 1 {
+		return nil, fmt.Errorf("ambiguous media type: %s", name)
+	}
+	return &mediaTypes[0], nil
+}
+
+func (c *Client) MediaTypeUpdate(ctx context.Context, id string, mt MediaType) error {
+	params, err := c.mediaTypeParams(ctx, mt)
+	if err != nil {
+		return err
+	}
+	params["mediatypeid"] = id
+	var ignored any
+	return c.callAuth(ctx, "mediatype.update", params, &ignored)
+}
+
+func (c *Client) MediaTypeDelete(ctx context.Context, id string) error {
+	var ignored any
+	return c.callAuth(ctx, "mediatype.delete", []string{id}, &ignored)
+}
+
+// --- Value map ---
+
+// Value map mapping types (Zabbix 6.0+; before, every mapping is an exact match).
+const (
+	ValueMapMappingEqual          = 0
+	ValueMapMappingGreaterOrEqual = 1
+	ValueMapMappingLessOrEqual    = 2
+	ValueMapMappingRange          = 3
+	ValueMapMappingRegexp         = 4
+	ValueMapMappingDefault        = 5
+)
+
+type ValueMapMapping struct {
+	Type     string `json:"type"`
+	Value    string `json:"value"`
+	NewValue string `json:"newvalue"`
+}
+
+type ValueMap struct {
+	ValueMapID string            `json:"valuemapid"`
+	HostID     string            `json:"hostid"`
+	Name       string            `json:"name"`
+	Mappings   []ValueMapMapping `json:"mappings"`
+}
+
+// valueMapMappingsParam omits the type of exact mappings, so that value maps also work before Zabbix 6.0.
+func valueMapMappingsParam(mappings []ValueMapMapping) []map[string]string {
+	out := make([]map[string]string, 0, len(mappings))
+	for _, m := range mappings {
+		mapping := map[string]string{"value": m.Value, "newvalue": m.NewValue}
+		if m.Type != "" && m.Type != strconv.Itoa(ValueMapMappingEqual) {
+			mapping["type"] = m.Type
+		}
+		out = append(out, mapping)
+	}
+	return out
+}
+
+func (c *Client) ValueMapCreate(ctx context.Context, hostID, name string, mappings []ValueMapMapping) (string, error) {
+	params := map[string]any{
+		"hostid":   hostID,
+		"name":     name,
+		"mappings": valueMapMappingsParam(mappings),
+	}
+	var result struct {
+		ValueMapIDs []string `json:"valuemapids"`
+	}
+	if err := c.callAuth(ctx, "valuemap.create", params, &result); err != nil {
+		return "", err
+	}
+	if len(result.ValueMapIDs) == 0 {
+		return "", errors.New("valuemap.create returned no valuemapid")
+	}
+	return result.ValueMapIDs[0], nil
+}
+
+func (c *Client) ValueMapGetByID(ctx context.Context, id string) (*ValueMap, error) {
+	params := map[string]any{
+		"valuemapids":    []string{id},
+		"output":         "extend",
+		"selectMappings": "extend",
+	}
+	var valueMaps []ValueMap
+	if err := c.callAuth(ctx, "valuemap.get", params, &valueMaps); err != nil {
+		return nil, err
+	}
+	if len(valueMaps) == 0 {
+		return nil, ErrNotFound
+	}
+	return &valueMaps[0], nil
+}
+
+func (c *Client) ValueMapUpdate(ctx context.Context, id, name string, mappings []ValueMapMapping) error {
+	params := map[string]any{
+		"valuemapid": id,
+		"name":       name,
+		"mappings":   valueMapMappingsParam(mappings),
+	}
+	var ignored any
+	return c.callAuth(ctx, "valuemap.update", params, &ignored)
+}
+
+func (c *Client) ValueMapDelete(ctx context.Context, id string) error {
+	var ignored any
+	return c.callAuth(ctx, "valuemap.delete", []string{id}, &ignored)
+}
+
+// --- Discovery rule (low-level discovery) ---
+
+// LLD filter condition operators.
+const (
+	LLDOperatorMatches    = 8
+	LLDOperatorNotMatches = 9
+	LLDOperatorExists     = 12
+	LLDOperatorNotExists  = 13
+)
+
+// LLD override operation objects (operationobject).
+const (
+	LLDObjectItemPrototype    = 0
+	LLDObjectTriggerPrototype = 1
+	LLDObjectGraphPrototype   = 2
+	LLDObjectHostPrototype    = 3
+)
+
+type LLDCondition struct {
+	Macro     string  `json:"macro"`
+	Value     string  `json:"value"`
+	Operator  FlexInt `json:"operator"`
+	FormulaID string  `json:"formulaid,omitempty"` // label used in a custom formula (A, B, ...)
+}
+
+// LLDFilter is the filter of a discovery rule or of an override. EvalType uses the action codes
+// (0=and/or, 1=and, 2=or, 3=custom).
+type LLDFilter struct {
+	EvalType   FlexInt        `json:"evaltype"`
+	Formula    string         `json:"formula"`
+	Conditions []LLDCondition `json:"conditions"`
+}
+
+type lldFilterAlias LLDFilter
+
+// UnmarshalJSON accepts an empty list for a missing filter.
+func (f *LLDFilter) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if len(data) > 0 && data[0] == '[' {
+		*f = LLDFilter{}
+		return nil
+	}
+	return json.Unmarshal(data, (*lldFilterAlias)(f))
+}
+
+// lldFilterParam encodes a filter; the formula is only sent with a custom evaluation.
+func lldFilterParam(f LLDFilter) map[string]any {
+	conditions := f.Conditions
+	if conditions == nil {
+		conditions = []LLDCondition{}
+	}
+	filter := map[string]any{
+		"evaltype":   f.EvalType,
+		"conditions": conditions,
+	}
+	if f.EvalType == ActionEvalTypeCustom {
+		filter["formula"] = f.Formula
+	}
+	return filter
+}
+
+type LLDMacroPath struct {
+	LLDMacro string `json:"lld_macro"`
+	Path     string `json:"path"`
+}
+
+type LLDOverride struct {
+	Name       string                 `json:"name"`
+	Step       FlexInt                `json:"step"`
+	Stop       FlexInt                `json:"stop"` // 1: stop processing the next overrides on match
+	Filter     LLDFilter              `json:"filter"`
+	Operations []LLDOverrideOperation `json:"operations"`
+}
+
+// LLDOverrideOperation is an override operation. The Zabbix API nests each action in its own object
+// (opstatus, opdiscover, ...); empty fields are not sent.
+type LLDOverrideOperation struct {
+	OperationObject int
+	Operator        int // 0=equals, 1=does not equal, 2=contains, 3=does not contain, 4=matches, 5=does not match
+	Value           string
+	Status          string // 0=create enabled, 1=create disabled
+	Discover        string // 0=discover, 1=do not discover
+	Delay           string
+	History         string
+	Trends          string
+	Severity        string
+	Tags            []Tag
+	TemplateIDs     []string
+	InventoryMode   string // -1=disabled, 0=manual, 1=automatic
+}
+
+func (o LLDOverrideOperation) MarshalJSON() ([]byte, error) {
+	op := map[string]any{
+		"operationobject": o.OperationObject,
+		"operator":        o.Operator,
+		"value":           o.Value,
+	}
+	fields := []struct{ name, field, value string }{
+		{"opstatus", "status", o.Status},
+		{"opdiscover", "discover", o.Discover},
+		{"opperiod", "delay", o.Delay},
+		{"ophistory", "history", o.History},
+		{"optrends", "trends", o.Trends},
+		{"opseverity", "severity", o.Severity},
+		{"opinventory", "inventory_mode", o.InventoryMode},
+	}
+	for _, f := range fields {
+		if f.value != "" {
+			op[f.name] = map[string]string{f.field: f.value}
+		}
+	}
+	if len(o.Tags) > 0 {
+		op["optag"] = o.Tags
+	}
+	if len(o.TemplateIDs) > 0 {
+		templates := make([]map[string]string, 0, len(o.TemplateIDs))
+		for _, id := range o.TemplateIDs {
+			templates = append(templates, map[string]string{"templateid": id})
+		}
+		op["optemplate"] = templates
+	}
+	return json.Marshal(op)
+}
+
+func (o *LLDOverrideOperation) UnmarshalJSON(data []byte) error {
+	var aux struct {
+		OperationObject FlexInt         `json:"operationobject"`
+		Operator        FlexInt         `json:"operator"`
+		Value           string          `json:"value"`
+		OpStatus        json.RawMessage `json:"opstatus"`
+		OpDiscover      json.RawMessage `json:"opdiscover"`
+		OpPeriod        json.RawMessage `json:"opperiod"`
+		OpHistory       json.RawMessage `json:"ophistory"`
+		OpTrends        json.RawMessage `json:"optrends"`
+		OpSeverity      json.RawMessage `json:"opseverity"`
+		OpInventory     json.RawMessage `json:"opinventory"`
+		OpTag           []Tag           `json:"optag"`
+		OpTemplate      []struct {
+			TemplateID string `json:"templateid"`
+		} `json:"optemplate"`
+	}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	*o = LLDOverrideOperation{
+		OperationObject: int(aux.OperationObject),
+		Operator:        int(aux.Operator),
+		Value:           aux.Value,
+		Status:          lldOperationField(aux.OpStatus, "status"),
+		Discover:        lldOperationField(aux.OpDiscover, "discover"),
+		Delay:           lldOperationField(aux.OpPeriod, "delay"),
+		History:         lldOperationField(aux.OpHistory, "history"),
+		Trends:          lldOperationField(aux.OpTrends, "trends"),
+		Severity:        lldOperationField(aux.OpSeverity, "severity"),
+		InventoryMode:   lldOperationField(aux.OpInventory, "inventory_mode"),
+		Tags:            aux.OpTag,
+	}
+	for _, t := range aux.OpTemplate {
+		o.TemplateIDs = append(o.TemplateIDs, t.TemplateID)
+	}
+	return nil
+}
+
+// lldOperationField returns a field of an operation object (e.g. opstatus.status), "" when unset.
+func lldOperationField(raw json.RawMessage, field string) string {
+	var obj map[string]flexString
+	if err := json.Unmarshal(raw, &obj); err != nil {
+		return ""
+	}
+	return string(obj[field])
+}
+
+type DiscoveryRule struct {
+	Item
+	Lifetime            string         `json:"lifetime"`
+	LifetimeType        string         `json:"lifetime_type"`         // Zabbix 7.0+
+	EnabledLifetimeType string         `json:"enabled_lifetime_type"` // Zabbix 7.0+
+	EnabledLifetime     string         `json:"enabled_lifetime"`      // Zabbix 7.0+
+	Filter              LLDFilter      `json:"filter"`
+	LLDMacroPaths       []LLDMacroPath `json:"lld_macro_paths"`
+	Overrides           []LLDOverride  `json:"overrides"`
+}
+
+// UnmarshalJSON reads the item fields with Item.UnmarshalJSON, then the discovery fields.
+func (r *DiscoveryRule) UnmarshalJSON(data []byte) error {
+	if err := json.Unmarshal(data, &r.Item); err != nil {
+		return err
+	}
+	var aux struct {
+		Lifetime            string         `json:"lifetime"`
+		LifetimeType        string         `json:"lifetime_type"`
+		EnabledLifetimeType string         `json:"enabled_lifetime_type"`
+		EnabledLifetime     string         `json:"enabled_lifetime"`
+		Filter              LLDFilter      `json:"filter"`
+		LLDMacroPaths       []LLDMacroPath `json:"lld_macro_paths"`
+		Overrides           []LLDOverride  `json:"overrides"`
+	}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	r.Lifetime = aux.Lifetime
+	r.LifetimeType = aux.LifetimeType
+	r.EnabledLifetimeType = aux.EnabledLifetimeType
+	r.EnabledLifetime = aux.EnabledLifetime
+	r.Filter = aux.Filter
+	r.LLDMacroPaths = aux.LLDMacroPaths
+	r.Overrides = aux.Overrides
+	return nil
+}
+
+type DiscoveryRuleCreateRequest struct {
+	ItemCreateRequest // value type, units, history, trends, tags, value map and inventory link are not used
+
+	Lifetime string // empty: Zabbix default
+
+	// Zabbix 7.0+, empty: Zabbix default. Types: 0=after lifetime, 1=never, 2=immediately.
+	LifetimeType        string
+	EnabledLifetimeType string
+	EnabledLifetime     string
+
+	Filter        LLDFilter
+	LLDMacroPaths []LLDMacroPath
+	Overrides     []LLDOverride // in order; Step is set from the position
+}
+
+func (c *Client) discoveryRuleParams(ctx context.Context, req DiscoveryRuleCreateRequest) (map[string]any, error) {
+	params, err := c.itemSourceParams(ctx, req.ItemCreateRequest)
+	if err != nil {
+		return nil, err
+	}
+	if req.Lifetime != "" {
+		params["lifetime"] = req.Lifetime
+	}
+	if req.LifetimeType != "" || req.EnabledLifetimeType != "" || req.EnabledLifetime != "" {
+		ok, err := c.VersionAtLeast(ctx, 7, 0)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			return nil, errors.New("lifetime_type, enabled_lifetime_type and enabled_lifetime require Zabbix 7.0 or later")
+		}
+		if req.LifetimeType != "" {
+			params["lifetime_type"] = req.LifetimeType
+		}
+		if req.EnabledLifetimeType != "" {
+			params["enabled_lifetime_type"] = req.EnabledLifetimeType
+		}
+		if req.EnabledLifetime != "" {
+			params["enabled_lifetime"] = req.EnabledLifetime
+		}
+	}
+	params["filter"] = lldFilterParam(req.Filter)
+	macroPaths := req.LLDMacroPaths
+	if macroPaths == nil {
+		macroPaths = []LLDMacroPath{}
+	}
+	params["lld_macro_paths"] = macroPaths
+	overrides := make([]map[string]any, 0, len(req.Overrides))
+	for i, o := range req.Overrides {
+		operations := o.Operations
+		if operations == nil {
+			operations = []LLDOverrideOperation{}
+		}
+		override := map[string]any{
+			"name":       o.Name,
+			"step":       i + 1,
+			"stop":       o.Stop,
+			"operations": operations,
+		}
+		if len(o.Filter.Conditions) > 0 {
+			override["filter"] = lldFilterParam(o.Filter)
+		}
+		overrides = append(overrides, override)
+	}
+	params["overrides"] = overrides
+	return params, nil
+}
+
+func (c *Client) DiscoveryRuleCreate(ctx context.Context, req DiscoveryRuleCreateRequest) (string, error) {
+	params, err := c.discoveryRuleParams(ctx, req)
+	if err != nil {
+		return "", err
+	}
+	params["hostid"] = req.HostID
+	var result struct {
+		ItemIDs []string `json:"itemids"`
+	}
+	if err := c.callAuth(ctx, "discoveryrule.create", params, &result); err != nil {
+		return "", err
+	}
+	if len(result.ItemIDs) == 0 {
+		return "", errors.New("discoveryrule.create returned no itemid")
+	}
+	return result.ItemIDs[0], nil
+}
+
+func (c *Client) DiscoveryRuleGetByID(ctx context.Context, id string) (*DiscoveryRule, error) {
+	params := map[string]any{
+		"itemids":             []string{id},
+		"output":              "extend",
+		"selectFilter":        "extend",
+		"selectLLDMacroPaths": "extend",
+		"selectOverrides":     "extend",
+		"selectPreprocessing": "extend",
+	}
+	var rules []DiscoveryRule
+	if err := c.callAuth(ctx, "discoveryrule.get", params, &rules); err != nil {
+		return nil, err
+	}
+	if len(rules) == 0 {
+		return nil, ErrNotFound
+	}
+	return &rules[0], nil
+}
+
+func (c *Client) DiscoveryRuleUpdate(ctx context.Context, id string, req DiscoveryRuleCreateRequest) error {
+	params, err := c.discoveryRuleParams(ctx, req)
+	if err != nil {
+		return err
+	}
+	params["itemid"] = id
+	var ignored any
+	return c.callAuth(ctx, "discoveryrule.update", params, &ignored)
+}
+
+func (c *Client) DiscoveryRuleDelete(ctx context.Context, id string) error {
+	var ignored any
+	return c.callAuth(ctx, "discoveryrule.delete", []string{id}, &ignored)
+}
+
+// --- Graph ---
+
+// Graph types (graphtype).
+const (
+	GraphTypeNormal   = 0
+	GraphTypeStacked  = 1
+	GraphTypePie      = 2
+	GraphTypeExploded = 3
+)
+
+// Graph y axis minimum and maximum types (ymin_type, ymax_type).
+const (
+	GraphYAxisCalculated = 0
+	GraphYAxisFixed      = 1
+	GraphYAxisItem       = 2
+)
+
+type GraphItem struct {
+	ItemID    string  `json:"itemid"`
+	Color     string  `json:"color"`     // RRGGBB
+	CalcFnc   FlexInt `json:"calc_fnc"`  // 1=min, 2=avg, 4=max, 7=all, 9=last
+	DrawType  FlexInt `json:"drawtype"`  // 0=line, 1=filled region, 2=bold line, 3=dot, 4=dashed line, 5=gradient line
+	SortOrder FlexInt `json:"sortorder"` // position in the graph
+	Type      FlexInt `json:"type"`      // 0=simple, 2=graph sum (pie graphs)
+	YAxisSide FlexInt `json:"yaxisside"` // 0=left, 1=right
+}
+
+type Graph struct {
+	GraphID        string      `json:"graphid"`
+	Name           string      `json:"name"`
+	Width          FlexInt     `json:"width"`
+	Height         FlexInt     `json:"height"`
+	GraphType      FlexInt     `json:"graphtype"`
+	PercentLeft    string      `json:"percent_left"`
+	PercentRight   string      `json:"percent_right"`
+	Show3D         string      `json:"show_3d"`
+	ShowLegend     string      `json:"show_legend"`
+	ShowWorkPeriod string      `json:"show_work_period"`
+	ShowTriggers   string      `json:"show_triggers"`
+	YMinType       FlexInt     `json:"ymin_type"`
+	YMaxType       FlexInt     `json:"ymax_type"`
+	YAxisMin       string      `json:"yaxismin"`
+	YAxisMax       string      `json:"yaxismax"`
+	YMinItemID     string      `json:"ymin_itemid"`
+	YMaxItemID     string      `json:"ymax_itemid"`
+	Items          []GraphItem `json:"gitems"`
+}
+
+// GraphCreateRequest for creating or updating a graph or a graph prototype.
+type GraphCreateRequest struct {
+	Name           string
+	Width          int
+	Height         int
+	GraphType      int // GraphType*
+	PercentLeft    float64
+	PercentRight   float64
+	Show3D         bool // pie and exploded graphs
+	ShowLegend     bool
+	ShowWorkPeriod bool
+	ShowTriggers   bool
+	YMinType       int // GraphYAxis*
+	YMaxType       int
+	YAxisMin       float64 // with GraphYAxisFixed
+	YAxisMax       float64
+	YMinItemID     string // with GraphYAxisItem
+	YMaxItemID     string
+	Items          []GraphItem // in order; SortOrder is set from the position
+}
+
+// graphParams builds the fields shared by graph and graph prototype create and update calls.
+func graphParams(req GraphCreateRequest) map[string]any {
+	items := make([]map[string]any, 0, len(req.Items))
+	for i, item := range req.Items {
+		items = append(items, map[string]any{
+			"itemid":    item.ItemID,
+			"color":     item.Color,
+			"calc_fnc":  item.CalcFnc,
+			"drawtype":  item.DrawType,
+			"sortorder": i,
+			"type":      item.Type,
+			"yaxisside": item.YAxisSide,
+		})
+	}
+	params := map[string]any{
+		"name":             req.Name,
+		"width":            req.Width,
+		"height":           req.Height,
+		"graphtype":        req.GraphType,
+		"percent_left":     req.PercentLeft,
+		"percent_right":    req.PercentRight,
+		"show_3d":          BoolToInt(req.Show3D),
+		"show_legend":      BoolToInt(req.ShowLegend),
+		"show_work_period": BoolToInt(req.ShowWorkPeriod),
+		"show_triggers":    BoolToInt(req.ShowTriggers),
+		"ymin_type":        req.YMinType,
+		"ymax_type":        req.YMaxType,
+		"yaxismin":         req.YAxisMin,
+		"yaxismax":         req.YAxisMax,
+		"gitems":           items,
+	}
+	if req.YMinType == GraphYAxisItem {
+		params["ymin_itemid"] = req.YMinItemID
+	}
+	if req.YMaxType == GraphYAxisItem {
+		params["ymax_itemid"] = req.YMaxItemID
+	}
+	return params
+}
+
+func (c *Client) GraphCreate(ctx context.Context, req GraphCreateRequest) (string, error) {
+	var result struct {
+		GraphIDs []string `json:"graphids"`
+	}
+	if err := c.callAuth(ctx, "graph.create", graphParams(req), &result); err != nil {
+		return "", err
+	}
+	if len(result.GraphIDs) == 0 {
+		return "", errors.New("graph.create returned no graphid")
+	}
+	return result.GraphIDs[0], nil
+}
+
+func (c *Client) GraphGetByID(ctx context.Context, id string) (*Graph, error) {
+	params := map[string]any{
+		"graphids":         []string{id},
+		"output":           "extend",
+		"selectGraphItems": "extend",
+	}
+	var graphs []Graph
+	if err := c.callAuth(ctx, "graph.get", params, &graphs); err != nil {
+		return nil, err
+	}
+	if len(graphs) == 0 {
+		return nil, ErrNotFound
+	}
+	return &graphs[0], nil
+}
+
+func (c *Client) GraphUpdate(ctx context.Context, id string, req GraphCreateRequest) error {
+	params := graphParams(req)
+	params["graphid"] = id
+	var ignored any
+	return c.callAuth(ctx, "graph.update", params, &ignored)
+}
+
+func (c *Client) GraphDelete(ctx context.Context, id string) error {
+	var ignored any
+	return c.callAuth(ctx, "graph.delete", []string{id}, &ignored)
+}
+
+// --- LLD prototypes ---
+
+// LLDRuleRef is the discovery rule of a prototype (selectDiscoveryRule).
+type LLDRuleRef struct {
+	ItemID string `json:"itemid"`
+}
+
+type ItemPrototype struct {
+	Item
+	Discover      string     `json:"discover"` // 0=discover, 1=do not discover
+	DiscoveryRule LLDRuleRef `json:"discoveryRule"`
+}
+
+// UnmarshalJSON reads the item fields with Item.UnmarshalJSON, then the prototype fields.
+func (p *ItemPrototype) UnmarshalJSON(data []byte) error {
+	if err := json.Unmarshal(data, &p.Item); err != nil {
+		return err
+	}
+	var aux struct {
+		Discover      string     `json:"discover"`
+		DiscoveryRule LLDRuleRef `json:"discoveryRule"`
+	}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	p.Discover = aux.Discover
+	p.DiscoveryRule = aux.DiscoveryRule
+	return nil
+}
+
+type ItemPrototypeCreateRequest struct {
+	ItemCreateRequest // HostID empty on create: the host of the rule; InventoryLink is not used
+	RuleID            string
+	Discover          bool
+}
+
+func (c *Client) itemPrototypeParams(ctx context.Context, req ItemPrototypeCreateRequest) (map[string]any, error) {
+	params, err := c.itemParams(ctx, req.ItemCreateRequest)
+	if err != nil {
+		return nil, err
+	}
+	delete(params, "inventory_link")
+	params["discover"] = BoolToStatus(req.Discover)
+	return params, nil
+}
+
+func (c *Client) ItemPrototypeCreate(ctx context.Context, req ItemPrototypeCreateRequest) (string, error) {
+	if req.HostID == "" {
+		rule, err := c.DiscoveryRuleGetByID(ctx, req.RuleID)
+		if err != nil {
+			return "", fmt.Errorf("could not read discovery rule %s: %w", req.RuleID, err)
+		}
+		req.HostID = rule.HostID
+	}
+	params, err := c.itemPrototypeParams(ctx, req)
+	if err != nil {
+		return "", err
+	}
+	params["hostid"] = req.HostID
+	params["ruleid"] = req.RuleID
+	var result struct {
+		ItemIDs []string `json:"itemids"`
+	}
+	if err := c.callAuth(ctx, "itemprototype.create", params, &result); err != nil {
+		return "", err
+	}
+	if len(result.ItemIDs) == 0 {
+		return "", errors.New("itemprototype.create returned no itemid")
+	}
+	return result.ItemIDs[0], nil
+}
+
+func (c *Client) ItemPrototypeGetByID(ctx context.Context, id string) (*ItemPrototype, error) {
+	params := map[string]any{
+		"itemids":             []string{id},
+		"output":              "extend",
+		"selectPreprocessing": "extend",
+		"selectTags":          "extend",
+		"selectDiscoveryRule": []string{"itemid"},
+	}
+	var prototypes []ItemPrototype
+	if err := c.callAuth(ctx, "itemprototype.get", params, &prototypes); err != nil {
+		return nil, err
+	}
+	if len(prototypes) == 0 {
+		return nil, ErrNotFound
+	}
+	return &prototypes[0], nil
+}
+
+func (c *Client) ItemPrototypeUpdate(ctx context.Context, id string, req ItemPrototypeCreateRequest) error {
+	params, err := c.itemPrototypeParams(ctx, req)
+	if err != nil {
+		return err
+	}
+	params["itemid"] = id
+	var ignored any
+	return c.callAuth(ctx, "itemprototype.update", params, &ignored)
+}
+
+func (c *Client) ItemPrototypeDelete(ctx context.Context, id string) error {
+	var ignored any
+	return c.callAuth(ctx, "itemprototype.delete", []string{id}, &ignored)
+}
+
+type TriggerPrototype struct {
+	Trigger
+	Discover      string     `json:"discover"` // 0=discover, 1=do not discover
+	DiscoveryRule LLDRuleRef `json:"discoveryRule"`
+}
+
+// TriggerPrototypeCreateRequest for creating or updating a trigger prototype. The rule is the one of the
+// item prototypes in the expression.
+type TriggerPrototypeCreateRequest struct {
+	TriggerCreateRequest
+	Discover bool
+}
+
+func (c *Client) triggerPrototypeParams(ctx context.Context, req TriggerPrototypeCreateRequest) (map[string]any, error) {
+	params, err := c.triggerParams(ctx, req.TriggerCreateRequest)
+	if err != nil {
+		return nil, err
+	}
+	params["discover"] = BoolToStatus(req.Discover)
+	return params, nil
+}
+
+func (c *Client) TriggerPrototypeCreate(ctx context.Context, req TriggerPrototypeCreateRequest) (string, error) {
+	params, err := c.triggerPrototypeParams(ctx, req)
+	if err != nil {
+		return "", err
+	}
+	var result struct {
+		TriggerIDs []string `json:"triggerids"`
+	}
+	if err := c.callAuth(ctx, "triggerprototype.create", params, &result); err != nil {
+		return "", err
+	}
+	if len(result.TriggerIDs) == 0 {
+		return "", errors.New("triggerprototype.create returned no triggerid")
+	}
+	return result.TriggerIDs[0], nil
+}
+
+func (c *Client) TriggerPrototypeGetByID(ctx context.Context, id string) (*TriggerPrototype, error) {
+	params := map[string]any{
+		"triggerids":          []string{id},
+		"output":              "extend",
+		"selectTags":          "extend",
+		"selectDependencies":  []string{"triggerid"},
+		"selectDiscoveryRule": []string{"itemid"},
+		"expandExpression":    true,
+	}
+	var prototypes []TriggerPrototype
+	if err := c.callAuth(ctx, "triggerprototype.get", params, &prototypes); err != nil {
+		return nil, err
+	}
+	if len(prototypes) == 0 {
+		return nil, ErrNotFound
+	}
+	return &prototypes[0], nil
+}
+
+func (c *Client) TriggerPrototypeUpdate(ctx context.Context, id string, req TriggerPrototypeCreateRequest) error {
+	params, err := c.triggerPrototypeParams(ctx, req)
+	if err != nil {
+		return err
+	}
+	params["triggerid"] = id
+	var ignored any
+	return c.callAuth(ctx, "triggerprototype.update", params, &ignored)
+}
+
+func (c *Client) TriggerPrototypeDelete(ctx context.Context, id string) error {
+	var ignored any
+	return c.callAuth(ctx, "triggerprototype.delete", []string{id}, &ignored)
+}
+
+type GraphPrototype struct {
+	Graph
+	Discover      string     `json:"discover"` // 0=discover, 1=do not discover
+	DiscoveryRule LLDRuleRef `json:"discoveryRule"`
+}
+
+// GraphPrototypeCreateRequest for creating or updating a graph prototype. The rule is the one of the item
+// prototypes in the graph.
+type GraphPrototypeCreateRequest struct {
+	GraphCreateRequest
+	Discover bool
+}
+
+func (c *Client) GraphPrototypeCreate(ctx context.Context, req GraphPrototypeCreateRequest) (string, error) {
+	params := graphParams(req.GraphCreateRequest)
+	params["discover"] = BoolToStatus(req.Discover)
+	var result struct {
+		GraphIDs []string `json:"graphids"`
+	}
+	if err := c.callAuth(ctx, "graphprototype.create", params, &result); err != nil {
+		return "", err
+	}
+	if len(result.GraphIDs) == 0 {
+		return "", errors.New("graphprototype.create returned no graphid")
+	}
+	return result.GraphIDs[0], nil
+}
+
+func (c *Client) GraphPrototypeGetByID(ctx context.Context, id string) (*GraphPrototype, error) {
+	params := map[string]any{
+		"graphids":            []string{id},
+		"output":              "extend",
+		"selectGraphItems":    "extend",
+		"selectDiscoveryRule": []string{"itemid"},
+	}
+	var prototypes []GraphPrototype
+	if err := c.callAuth(ctx, "graphprototype.get", params, &prototypes); err != nil {
+		return nil, err
+	}
+	if len(prototypes) == 0 {
+		return nil, ErrNotFound
+	}
+	return &prototypes[0], nil
+}
+
+func (c *Client) GraphPrototypeUpdate(ctx context.Context, id string, req GraphPrototypeCreateRequest) error {
+	params := graphParams(req.GraphCreateRequest)
+	params["discover"] = BoolToStatus(req.Discover)
+	params["graphid"] = id
+	var ignored any
+	return c.callAuth(ctx, "graphprototype.update", params, &ignored)
+}
+
+func (c *Client) GraphPrototypeDelete(ctx context.Context, id string) error {
+	var ignored any
+	return c.callAuth(ctx, "graphprototype.delete", []string{id}, &ignored)
+}
+
+// HostPrototypeMacro is a user macro of a host prototype.
+type HostPrototypeMacro struct {
+	Macro string `json:"macro"`
+	Value string `json:"value"`
+}
+
+type HostPrototype struct {
+	HostID           string          `json:"hostid"`
+	Host             string          `json:"host"`
+	Name             string          `json:"name"`
+	Status           string          `json:"status"`
+	Discover         string          `json:"discover"`       // 0=discover, 1=do not discover
+	InventoryMode    FlexInt         `json:"inventory_mode"` // -1=disabled, 0=manual, 1=automatic
+	CustomInterfaces string          `json:"custom_interfaces"`
+	Interfaces       []HostInterface `json:"interfaces"`
+	GroupLinks       []struct {
+		GroupID string `json:"groupid"`
+	} `json:"groupLinks"`
+	GroupPrototypes []struct {
+		Name string `json:"name"`
+	} `json:"groupPrototypes"`
+	Templates []struct {
+		TemplateID string `json:"templateid"`
+	} `json:"templates"`
+	Macros        []HostPrototypeMacro `json:"macros"`
+	Tags          []Tag                `json:"tags"`
+	DiscoveryRule LLDRuleRef           `json:"discoveryRule"`
+}
+
+// HostPrototypeCreateRequest for creating or updating a host prototype. RuleID is only used on create.
+// Without interfaces the discovered hosts inherit the interfaces of the host of the rule.
+type HostPrototypeCreateRequest struct {
+	RuleID          string
+	Host            string
+	Name            string
+	Status          int
+	Discover        bool
+	InventoryMode   int
+	Interfaces      []HostInterface
+	GroupIDs        []string
+	GroupPrototypes []string
+	TemplateIDs     []string
+	Macros          []HostPrototypeMacro
+	Tags            []Tag
+}
+
+// hostPrototypeParams builds the fields shared by hostprototype.create and hostprototype.update.
+// Group links, group prototypes, templates, macros, tags and interfaces are always sent so that
+// removed entries are removed in Zabbix.
+func hostPrototypeParams(req HostPrototypeCreateRequest, maxRepetitions bool) map[string]any {
+	groupLinks := make([]map[string]string, 0, len(req.GroupIDs))
+	for _, id := range req.GroupIDs {
+		groupLinks = append(groupLinks, map[string]string{"groupid": id})
+	}
+	groupPrototypes := make([]map[string]string, 0, len(req.GroupPrototypes))
+	for _, name := range req.GroupPrototypes {
+		groupPrototypes = append(groupPrototypes, map[string]string{"name": name})
+	}
+	templates := make([]map[string]string, 0, len(req.TemplateIDs))
+	for _, id := range req.TemplateIDs {
+		templates = append(templates, map[string]string{"templateid": id})
+	}
+	macros := req.Macros
+	if macros == nil {
+		macros = []HostPrototypeMacro{}
+	}
+	tags := req.Tags
+	if tags == nil {
+		tags = []Tag{}
+	}
+	customInterfaces := 0
+	if len(req.Interfaces) > 0 {
+		customInterfaces = 1
+	}
+	return map[string]any{
+		"host":              req.Host,
+		"name":              req.Name,
+		"status":            req.Status,
+		"discover":          BoolToStatus(req.Discover),
+		"inventory_mode":    req.InventoryMode,
+		"custom_interfaces": customInterfaces,
+		"interfaces":        interfacesForHostCreate(req.Interfaces, maxRepetitions),
+		"groupLinks":        groupLinks,
+		"groupPrototypes":   groupPrototypes,
+		"templates":         templates,
+		"macros":            macros,
+		"tags":              tags,
+	}
+}
+
+func (c *Client) HostPrototypeCreate(ctx context.Context, req HostPrototypeCreateRequest) (string, error) {
+	maxRepetitions, err := c.VersionAtLeast(ctx, 7, 0)
+	if err != nil {
+		return "", err
+	}
+	params := hostPrototypeParams(req, maxRepetitions)
+	params["ruleid"] = req.RuleID
+	var result struct {
+		HostIDs []string `json:"hostids"`
+	}
+	if err := c.callAuth(ctx, "hostprototype.create", params, &result); err != nil {
+		return "", err
+	}
+	if len(result.HostIDs) == 0 {
+		return "", errors.New("hostprototype.create returned no hostid")
+	}
+	return result.HostIDs[0], nil
+}
+
+func (c *Client) HostPrototypeGetByID(ctx context.Context, id string) (*HostPrototype, error) {
+	params := map[string]any{
+		"hostids":               []string{id},
+		"output":                "extend",
+		"selectInterfaces":      "extend",
+		"selectGroupLinks":      []string{"groupid"},
+		"selectGroupPrototypes": []string{"name"},
+		"selectTemplates":       []string{"templateid"},
+		"selectMacros":          []string{"macro", "value"},
+		"selectTags":            "extend",
+		"selectDiscoveryRule":   []string{"itemid"},
+	}
+	var prototypes []HostPrototype
+	if err := c.callAuth(ctx, "hostprototype.get", params, &prototypes); err != nil {
+		return nil, err
+	}
+	if len(prototypes) == 0 {
+		return nil, ErrNotFound
+	}
+	return &prototypes[0], nil
+}
+
+func (c *Client) HostPrototypeUpdate(ctx context.Context, id string, req HostPrototypeCreateRequest) error {
+	maxRepetitions, err := c.VersionAtLeast(ctx, 7, 0)
+	if err != nil {
+		return err
+	}
+	params := hostPrototypeParams(req, maxRepetitions)
+	params["hostid"] = id
+	var ignored any
+	return c.callAuth(ctx, "hostprototype.update", params, &ignored)
+}
+
+func (c *Client) HostPrototypeDelete(ctx context.Context, id string) error {
+	var ignored any
+	return c.callAuth(ctx, "hostprototype.delete", []string{id}, &ignored)
+}
+
+// --- Dashboard ---
+
+// DashboardWidgetField is a widget setting. Type is 0=integer, 1=string, 2=host group, 3=host, 4=item,
+// 5=item prototype, 6=graph, 7=graph prototype, 8=map, 9=service, 10=SLA, 11=user, 12=action, 13=media type;
+// the value of reference types is the ID of the object.
+type DashboardWidgetField struct {
+	Type  FlexInt `json:"type"`
+	Name  string  `json:"name"`
+	Value string  `json:"value"`
+}
+
+func (f *DashboardWidgetField) UnmarshalJSON(data []byte) error {
+	var aux struct {
+		Type  FlexInt    `json:"type"`
+		Name  string     `json:"name"`
+		Value flexString `json:"value"`
+	}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	f.Type = aux.Type
+	f.Name = aux.Name
+	f.Value = string(aux.Value)
+	return nil
+}
+
+type DashboardWidget struct {
+	Type     string                 `json:"type"`
+	Name     string                 `json:"name"`
+	X        FlexInt                `json:"x"`
+	Y        FlexInt                `json:"y"`
+	Width    FlexInt                `json:"width"`
+	Height   FlexInt                `json:"height"`
+	ViewMode FlexInt                `json:"view_mode"` // 0=default, 1=hidden header
+	Fields   []DashboardWidgetField `json:"fields"`
+}
+
+type DashboardPage struct {
+	Name          string            `json:"name"`
+	DisplayPeriod FlexInt           `json:"display_period"` // 0=dashboard default
+	Widgets       []DashboardWidget `json:"widgets"`
+}
+
+// DashboardShare is a user or user group a dashboard is shared with. Permission is 2=read or 3=read-write.
+type DashboardShare struct {
+	ID         string
+	Permission string
+}
+
+type Dashboard struct {
+	DashboardID   string  `json:"dashboardid"`
+	Name          string  `json:"name"`
+	DisplayPeriod FlexInt `json:"display_period"`
+	AutoStart     string  `json:"auto_start"`
+	Private       string  `json:"private"`
+	UserID        string  `json:"userid"`
+	Users         []struct {
+		UserID     string `json:"userid"`
+		Permission string `json:"permission"`
+	} `json:"users"`
+	UserGroups []struct {
+		UserGroupID string `json:"usrgrpid"`
+		Permission  string `json:"permission"`
+	} `json:"userGroups"`
+	Pages []DashboardPage `json:"pages"`
+}
+
+// DashboardCreateRequest for creating or updating a dashboard. Pages and sharing are replaced on update.
+type DashboardCreateRequest struct {
+	Name          string
+	DisplayPeriod int
+	AutoStart     bool
+	Private       bool
+	OwnerID       string // empty: the API user
+	Users         []DashboardShare
+	UserGroups    []DashboardShare
+	Pages         []DashboardPage
+}
+
+// dashboardPagesParam returns the pages with empty lists instead of nil, as the API rejects null.
+func dashboardPagesParam(pages []DashboardPage) []DashboardPage {
+	out := make([]DashboardPage, 0, len(pages))
+	for _, page := range pages {
+		widgets := make([]DashboardWidget, 0, len(page.Widgets))
+		for _, widget := range page.Widgets {
+			if widget.Fields == nil {
+				widget.Fields = []DashboardWidgetField{}
+			}
+			widgets = append(widgets, widget)
+		}
+		page.Widgets = widgets
+		out = append(out, page)
+	}
+	return out
+}
+
+func dashboardParams(req DashboardCreateRequest) map[string]any {
+	users := make([]map[string]string, 0, len(req.Users))
+	for _, u := range req.Users {
+		users = append(users, map[string]string{"userid": u.ID, "permission": u.Permission})
+	}
+	userGroups := make([]map[string]string, 0, len(req.UserGroups))
+	for _, g := range req.UserGroups {
+		userGroups = append(userGroups, map[string]string{"usrgrpid": g.ID, "permission": g.Permission})
+	}
+	params := map[string]any{
+		"name":           req.Name,
+		"display_period": req.DisplayPeriod,
+		"auto_start":     BoolToInt(req.AutoStart),
+		"private":        BoolToInt(req.Private),
+		"users":          users,
+		"userGroups":     userGroups,
+		"pages":          dashboardPagesParam(req.Pages),
+	}
+	if req.OwnerID != "" {
+		params["userid"] = req.OwnerID
+	}
+	return params
+}
+
+func (c *Client) DashboardCreate(ctx context.Context, req DashboardCreateRequest) (string, error) {
+	var result struct {
+		DashboardIDs []string `json:"dashboardids"`
+	}
+	if err := c.callAuth(ctx, "dashboard.create", dashboardParams(req), &result); err != nil {
+		return "", err
+	}
+	if len(result.DashboardIDs) == 0 {
+		return "", errors.New("dashboard.create returned no dashboardid")
+	}
+	return result.DashboardIDs[0], nil
+}
+
+func (c *Client) DashboardGetByID(ctx context.Context, id string) (*Dashboard, error) {
+	params := map[string]any{
+		"dashboardids":     []string{id},
+		"output":           "extend",
+		"selectPages":      "extend",
+		"selectUsers":      "extend",
+		"selectUserGroups": "extend",
+	}
+	var dashboards []Dashboard
+	if err := c.callAuth(ctx, "dashboard.get", params, &dashboards); err != nil {
+		return nil, err
+	}
+	if len(dashboards) == 0 {
+		return nil, ErrNotFound
+	}
+	return &dashboards[0], nil
+}
+
+func (c *Client) DashboardUpdate(ctx context.Context, id string, req DashboardCreateRequest) error {
+	params := dashboardParams(req)
+	params["dashboardid"] = id
+	var ignored any
+	return c.callAuth(ctx, "dashboard.update", params, &ignored)
+}
+
+func (c *Client) DashboardDelete(ctx context.Context, id string) error {
+	var ignored any
+	return c.callAuth(ctx, "dashboard.delete", []string{id}, &ignored)
+}
+
+// --- Template dashboard ---
+
+type TemplateDashboard struct {
+	DashboardID   string          `json:"dashboardid"`
+	TemplateID    string          `json:"templateid"`
+	Name          string          `json:"name"`
+	DisplayPeriod FlexInt         `json:"display_period"`
+	AutoStart     string          `json:"auto_start"`
+	Pages         []DashboardPage `json:"pages"`
+}
+
+// TemplateDashboardCreateRequest for creating or updating a template dashboard. TemplateID is only used on create.
+type TemplateDashboardCreateRequest struct {
+	TemplateID    string
+	Name          string
+	DisplayPeriod int
+	AutoStart     bool
+	Pages         []DashboardPage
+}
+
+func templateDashboardParams(req TemplateDashboardCreateRequest) map[string]any {
+	return map[string]any{
+		"name":           req.Name,
+		"display_period": req.DisplayPeriod,
+		"auto_start":     BoolToInt(req.AutoStart),
+		"pages":          dashboardPagesParam(req.Pages),
+	}
+}
+
+func (c *Client) TemplateDashboardCreate(ctx context.Context, req TemplateDashboardCreateRequest) (string, error) {
+	params := templateDashboardParams(req)
+	params["templateid"] = req.TemplateID
+	var result struct {
+		DashboardIDs []string `json:"dashboardids"`
+	}
+	if err := c.callAuth(ctx, "templatedashboard.create", params, &result); err != nil {
+		return "", err
+	}
+	if len(result.DashboardIDs) == 0 {
+		return "", errors.New("templatedashboard.create returned no dashboardid")
+	}
+	return result.DashboardIDs[0], nil
+}
+
+func (c *Client) TemplateDashboardGetByID(ctx context.Context, id string) (*TemplateDashboard, error) {
+	params := map[string]any{
+		"dashboardids": []string{id},
+		"output":       "extend",
+		"selectPages":  "extend",
+	}
+	var dashboards []TemplateDashboard
+	if err := c.callAuth(ctx, "templatedashboard.get", params, &dashboards); err != nil {
+		return nil, err
+	}
+	if len(dashboards) == 0 {
+		return nil, ErrNotFound
+	}
+	return &dashboards[0], nil
+}
+
+func (c *Client) TemplateDashboardUpdate(ctx context.Context, id string, req TemplateDashboardCreateRequest) error {
+	params := templateDashboardParams(req)
+	params["dashboardid"] = id
+	var ignored any
+	return c.callAuth(ctx, "templatedashboard.update", params, &ignored)
+}
+
+func (c *Client) TemplateDashboardDelete(ctx context.Context, id string) error {
+	var ignored any
+	return c.callAuth(ctx, "templatedashboard.delete", []string{id}, &ignored)
+}
+
+// --- Maintenance ---
+
+// Maintenance timeperiod types (timeperiod_type).
+const (
+	MaintenancePeriodOneTime = 0
+	MaintenancePeriodDaily   = 2
+	MaintenancePeriodWeekly  = 3
+	MaintenancePeriodMonthly = 4
+)
+
+// MaintenanceTimePeriod is a maintenance period. Weekdays and months are bitmasks (1=Monday, 1=January).
+type MaintenanceTimePeriod struct {
+	Type      FlexInt `json:"timeperiod_type"`
+	Every     FlexInt `json:"every"`
+	Month     FlexInt `json:"month"`
+	DayOfWeek FlexInt `json:"dayofweek"`
+	Day       FlexInt `json:"day"`
+	StartTime FlexInt `json:"start_time"` // seconds since midnight
+	Period    FlexInt `json:"period"`     // seconds
+	StartDate FlexInt `json:"start_date"` // Unix time, one-time periods
+}
+
+// MaintenanceTag is a problem tag filter. Operator is 0=equals or 2=contains.
+type MaintenanceTag struct {
+	Tag      string  `json:"tag"`
+	Operator FlexInt `json:"operator"`
+	Value    string  `json:"value"`
+}
+
+type Maintenance struct {
+	MaintenanceID   string  `json:"maintenanceid"`
+	Name            string  `json:"name"`
+	Description     string  `json:"description"`
+	MaintenanceType FlexInt `json:"maintenance_type"` // 0=with data collection, 1=without
+	ActiveSince     FlexInt `json:"active_since"`
+	ActiveTill      FlexInt `json:"active_till"`
+	TagsEvalType    FlexInt `json:"tags_evaltype"` // 0=and/or, 2=or
+	Hosts           []struct {
+		HostID string `json:"hostid"`
+	} `json:"hosts"`
+	HostGroups []struct {
+		GroupID string `json:"groupid"`
+	} `json:"hostgroups"`
+	TimePeriods []MaintenanceTimePeriod `json:"timeperiods"`
+	Tags        []MaintenanceTag        `json:"tags"`
+}
+
+// MaintenanceCreateRequest for creating or updating a maintenance. Hosts, groups, periods and tags are replaced on update.
+type MaintenanceCreateRequest struct {
+	Name            string
+	Description     string
+	MaintenanceType int
+	ActiveSince     int64
+	ActiveTill      int64
+	TagsEvalType    int
+	HostIDs         []string
+	GroupIDs        []string
+	TimePeriods     []MaintenanceTimePeriod
+	Tags            []MaintenanceTag // with data collection only
+}
+
+// maintenanceTimePeriodsParam sends only the fields of each period type, as Zabbix 7.0 rejects the others.
+func maintenanceTimePeriodsParam(periods []MaintenanceTimePeriod) []map[string]any {
+	out := make([]map[string]any, 0, len(periods))
+	for _, p := range periods {
+		m := map[string]any{
+			"timeperiod_type": p.Type,
+			"period":          p.Period,
+		}
+		switch p.Type {
+		case MaintenancePeriodOneTime:
+			m["start_date"] = p.StartDate
+		case MaintenancePeriodDaily:
+			m["every"] = p.Every
+			m["start_time"] = p.StartTime
+		case MaintenancePeriodWeekly:
+			m["every"] = p.Every
+			m["dayofweek"] = p.DayOfWeek
+			m["start_time"] = p.StartTime
+		case MaintenancePeriodMonthly:
+			m["month"] = p.Month
+			m["start_time"] = p.StartTime
+			if p.DayOfWeek != 0 {
+				m["every"] = p.Every
+				m["dayofweek"] = p.DayOfWeek
+			} else {
+				m["day"] = p.Day
+			}
+		}
+		out = append(out, m)
+	}
+	return out
+}
+
+func maintenanceParams(req MaintenanceCreateRequest) map[string]any {
+	hosts := make([]map[string]string, 0, len(req.HostIDs))
+	for _, id := range req.HostIDs {
+		hosts = append(hosts, map[string]string{"hostid": id})
+	}
+	groups := make([]map[string]string, 0, len(req.GroupIDs))
+	for _, id := range req.GroupIDs {
+		groups = append(groups, map[string]string{"groupid": id})
+	}
+	params := map[string]any{
+		"name":             req.Name,
+		"description":      req.Description,
+		"maintenance_type": req.MaintenanceType,
+		"active_since":     req.ActiveSince,
+		"active_till":      req.ActiveTill,
+		"hosts":            hosts,
+		"groups":           groups,
+		"timeperiods":      maintenanceTimePeriodsParam(req.TimePeriods),
+	}
+	if req.MaintenanceType == 0 {
+		tags := req.Tags
+		if tags == nil {
+			tags = []MaintenanceTag{}
+		}
+		params["tags_evaltype"] = req.TagsEvalType
+		params["tags"] = tags
+	}
+	return params
+}
+
+func (c *Client) MaintenanceCreate(ctx context.Context, req MaintenanceCreateRequest) (string, error) {
+	var result struct {
+		MaintenanceIDs []string `json:"maintenanceids"`
+	}
+	if err := c.callAuth(ctx, "maintenance.create", maintenanceParams(req), &result); err != nil {
+		return "", err
+	}
+	if len(result.MaintenanceIDs) == 0 {
+		return "", errors.New("maintenance.create returned no maintenanceid")
+	}
+	return result.MaintenanceIDs[0], nil
+}
+
+func (c *Client) MaintenanceGetByID(ctx context.Context, id string) (*Maintenance, error) {
+	params := map[string]any{
+		"maintenanceids":    []string{id},
+		"output":            "extend",
+		"selectHosts":       []string{"hostid"},
+		"selectHostGroups":  []string{"groupid"},
+		"selectTimeperiods": "extend",
+		"selectTags":        "extend",
+	}
+	var maintenances []Maintenance
+	if err := c.callAuth(ctx, "maintenance.get", params, &maintenances); err != nil {
+		return nil, err
+	}
+	if len(maintenances) == 0 {
+		return nil, ErrNotFound
+	}
+	return &maintenances[0], nil
+}
+
+func (c *Client) MaintenanceUpdate(ctx context.Context, id string, req MaintenanceCreateRequest) error {
+	params := maintenanceParams(req)
+	params["maintenanceid"] = id
+	var ignored any
+	return c.callAuth(ctx, "maintenance.update", params, &ignored)
+}
+
+func (c *Client) MaintenanceDelete(ctx context.Context, id string) error {
+	var ignored any
+	return c.callAuth(ctx, "maintenance.delete", []string{id}, &ignored)
+}
+
+// --- Proxy ---
+
+// Proxy operating modes (Zabbix 7.0 operating_mode; status 5/6 before 7.0).
+const (
+	ProxyModeActive  = 0
+	ProxyModePassive = 1
+)
+
+// TLS connection bits of tls_connect and tls_accept.
+const (
+	TLSNoEncryption = 1
+	TLSPSK          = 2
+	TLSCertificate  = 4
+)
+
+// Proxy is normalized to the Zabbix 7.0 fields; older servers are mapped on read.
+type Proxy struct {
+	ProxyID          string
+	Name             string
+	OperatingMode    int
+	Description      string
+	Address          string
+	Port             string
+	AllowedAddresses string
+	ProxyGroupID     string
+	LocalAddress     string
+	LocalPort        string
+	TLSConnect       int
+	TLSAccept        int
+	TLSIssuer        string
+	TLSSubject       string
+}
+
+type proxyJSON struct {
+	ProxyID          string  `json:"proxyid"`
+	Name             string  `json:"name"`
+	OperatingMode    FlexInt `json:"operating_mode"`
+	Description      string  `json:"description"`
+	Address          string  `json:"address"`
+	Port             string  `json:"port"`
+	AllowedAddresses string  `json:"allowed_addresses"`
+	ProxyGroupID     string  `json:"proxy_groupid"`
+	LocalAddress     string  `json:"local_address"`
+	LocalPort        string  `json:"local_port"`
+	TLSConnect       FlexInt `json:"tls_connect"`
+	TLSAccept        FlexInt `json:"tls_accept"`
+	TLSIssuer        string  `json:"tls_issuer"`
+	TLSSubject       string  `json:"tls_subject"`
+	// Before 7.0.
+	Host         string          `json:"host"`
+	Status       FlexInt         `json:"status"`
+	ProxyAddress string          `json:"proxy_address"`
+	Interface    json.RawMessage `json:"interface"`
+}
+
+func (p *Proxy) UnmarshalJSON(data []byte) error {
+	var raw proxyJSON
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	*p = Proxy{
+		ProxyID:          raw.ProxyID,
+		Name:             raw.Name,
+		OperatingMode:    int(raw.OperatingMode),
+		Description:      raw.Description,
+		Address:          raw.Address,
+		Port:             raw.Port,
+		AllowedAddresses: raw.AllowedAddresses,
+		ProxyGroupID:     raw.ProxyGroupID,
+		LocalAddress:     raw.LocalAddress,
+		LocalPort:        raw.LocalPort,
+		TLSConnect:       int(raw.TLSConnect),
+		TLSAccept:        int(raw.TLSAccept),
+		TLSIssuer:        raw.TLSIssuer,
+		TLSSubject:       raw.TLSSubject,
+	}
+	if raw.Host != "" && raw.Name == "" {
+		p.Name = raw.Host
+		p.AllowedAddresses = raw.ProxyAddress
+		p.OperatingMode = ProxyModeActive
+		if raw.Status == 6 {
+			p.OperatingMode = ProxyModePassive
+		}
+		// The interface of passive proxies is an object; active proxies have an empty array.
+		var iface struct {
+			UseIP FlexInt `json:"useip"`
+			IP    string  `json:"ip"`
+			DNS   string  `json:"dns"`
+			Port  string  `json:"port"`
+		}
+		if len(raw.Interface) > 0 && raw.Interface[0] == '{' {
+			if err := json.Unmarshal(raw.Interface, &iface); err != nil {
+				return err
+			}
+			p.Address = iface.DNS
+			if iface.UseIP == 1 {
+				p.Address = iface.IP
+			}
+			p.Port = iface.Port
+		}
+	}
+	return nil
+}
+
+type ProxyCreateRequest struct {
+	Name             string
+	OperatingMode    int
+	Description      string
+	Address          string
+	Port             string
+	AllowedAddresses string
+	ProxyGroupID     string
+	LocalAddress     string
+	LocalPort        string
+	TLSConnect       int
+	TLSAccept        int
+	TLSIssuer        string
+	TLSSubject       string
+	TLSPSKIdentity   string
+	TLSPSK           string
+}
+
+// proxyParams builds proxy.create/update params; the PSK is only sent when a PSK connection is used.
+func (c *Client) proxyParams(ctx context.Context, req ProxyCreateRequest) (map[string]any, error) {
+	params := map[string]any{
+		"description": req.Description,
+		"tls_connect": req.TLSConnect,
+		"tls_accept":  req.TLSAccept,
+		"tls_issuer":  req.TLSIssuer,
+		"tls_subject": req.TLSSubject,
+	}
+	if (req.TLSConnect|req.TLSAccept)&TLSPSK != 0 {
+		params["tls_psk_identity"] = req.TLSPSKIdentity
+		params["tls_psk"] = req.TLSPSK
+	}
+
+	v70, err := c.VersionAtLeast(ctx, 7, 0)
+	if err != nil {
+		return nil, err
+	}
+	if v70 {
+		params["name"] = req.Name
+		params["operating_mode"] = req.OperatingMode
+		params["proxy_groupid"] = "0"
+		if req.ProxyGroupID != "" {
+			params["proxy_groupid"] = req.ProxyGroupID
+			params["local_address"] = req.LocalAddress
+			params["local_port"] = req.LocalPort
+		}
+		if req.OperatingMode == ProxyModePassive {
+			params["address"] = req.Address
+			params["port"] = req.Port
+		} else {
+			params["allowed_addresses"] = req.AllowedAddresses
+		}
+		return params, nil
+	}
+
+	if req.ProxyGroupID != "" {
+		return nil, errors.New("proxy groups require Zabbix 7.0 or later")
+	}
+	params["host"] = req.Name
+	if req.OperatingMode == ProxyModePassive {
+		params["status"] = 6
+		iface := map[string]any{"useip": 0, "ip": "", "dns": req.Address, "port": req.Port}
+		if net.ParseIP(req.Address) != nil {
+			iface["useip"] = 1
+			iface["ip"] = req.Address
+			iface["dns"] = ""
+		}
+		params["interface"] = iface
+	} else {
+		params["status"] = 5
+		params["proxy_address"] = req.AllowedAddresses
+	}
+	return params, nil
+}
+
+func (c *Client) ProxyCreate(ctx context.Context, req ProxyCreateRequest) (string, error) {
+	params, err := c.proxyParams(ctx, req)
+	if err != nil {
+		return "", err
+	}
+	var result struct {
+		ProxyIDs []string `json:"proxyids"`
+	}
+	if err := c.callAuth(ctx, "proxy.create", params, &result); err != nil {
+		return "", err
+	}
+	if len(result.ProxyIDs) == 0 {
+		return "", errors.New("proxy.create returned no proxyid")
+	}
+	return result.ProxyIDs[0], nil
+}
+
+func (c *Client) ProxyGetByID(ctx context.Context, id string) (*Proxy, error) {
+	params := map[string]any{
+		"proxyids": []string{id},
+		"output":   "extend",
+	}
+	v70, err := c.VersionAtLeast(ctx, 7, 0)
+	if err != nil {
+		return nil, err
+	}
+	if !v70 {
+		params["selectInterface"] = "extend"
+	}
+	var proxies []Proxy
+	if err := c.callAuth(ctx, "proxy.get", params, &proxies); err != nil {
+		return nil, err
+	}
+	if len(proxies) == 0 {
+		return nil, ErrNotFound
+	}
+	return &proxies[0], nil
+}
+
+// ProxyIDByName returns the ID of the proxy with this name.
+func (c *Client) ProxyIDByName(ctx context.Context, name string) (string, error) {
+	v70, err := c.VersionAtLeast(ctx, 7, 0)
+	if err != nil {
+		return "", err
+	}
+	nameField := "host"
+	if v70 {
+		nameField = "name"
+	}
+	params := map[string]any{
+		"output": []string{"proxyid"},
+		"filter": map[string]any{nameField: []string{name}},
+	}
+	var proxies []struct {
+		ProxyID string `json:"proxyid"`
+	}
+	if err := c.callAuth(ctx, "proxy.get", params, &proxies); err != nil {
+		return "", err
+	}
+	if len(proxies) == 0 {
+		return "", fmt.Errorf("proxy not found: %s", name)
+	}
+	if len(proxies) > 1 {
+		return "", fmt.Errorf("ambiguous proxy: %s", name)
+	}
+	return proxies[0].ProxyID, nil
+}
+
+func (c *Client) ProxyUpdate(ctx context.Context, id string, req ProxyCreateRequest) error {
+	params, err := c.proxyParams(ctx, req)
+	if err != nil {
+		return err
+	}
+	params["proxyid"] = id
+	var ignored any
+	return c.callAuth(ctx, "proxy.update", params, &ignored)
+}
+
+func (c *Client) ProxyDelete(ctx context.Context, id string) error {
+	var ignored any
+	return c.callAuth(ctx, "proxy.delete", []string{id}, &ignored)
+}
+
+// --- Proxy group (Zabbix 7.0+) ---
+
+type ProxyGroup struct {
+	ProxyGroupID  string `json:"proxy_groupid"`
+	Name          string `json:"name"`
+	Description   string `json:"description"`
+	FailoverDelay string `json:"failover_delay"`
+	MinOnline     string `json:"min_online"`
+}
+
+// requireProxyGroups fails on servers without proxy groups.
+func (c *Client) requireProxyGroups(ctx context.Context) error {
+	ok, err := c.VersionAtLeast(ctx, 7, 0)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return errors.New("proxy groups require Zabbix 7.0 or later")
+	}
+	return nil
+}
+
+func proxyGroupParams(group ProxyGroup) map[string]any {
+	return map[string]any{
+		"name":           group.Name,
+		"description":    group.Description,
+		"failover_delay": group.FailoverDelay,
+		"min_online":     group.MinOnline,
+	}
+}
+
+func (c *Client) ProxyGroupCreate(ctx context.Context, group ProxyGroup) (string, error) {
+	if err := c.requireProxyGroups(ctx); err != nil {
+		return "", err
+	}
+	var result struct {
+		ProxyGroupIDs []string `json:"proxy_groupids"`
+	}
+	if err := c.callAuth(ctx, "proxygroup.create", proxyGroupParams(group), &result); err != nil {
+		return "", err
+	}
+	if len(result.ProxyGroupIDs) == 0 {
+		return "", errors.New("proxygroup.create returned no proxy_groupid")
+	}
+	return result.ProxyGroupIDs[0], nil
+}
+
+func (c *Client) ProxyGroupGetByID(ctx context.Context, id string) (*ProxyGroup, error) {
+	if err := c.requireProxyGroups(ctx); err != nil {
+		return nil, err
+	}
+	params := map[string]any{
+		"proxy_groupids": []string{id},
+		"output":         []string{"proxy_groupid", "name", "description", "failover_delay", "min_online"},
+	}
+	var groups []ProxyGroup
+	if err := c.callAuth(ctx, "proxygroup.get", params, &groups); err != nil {
+		return nil, err
+	}
+	if len(groups) == 0 {
+		return nil, ErrNotFound
+	}
+	return &groups[0], nil
+}
+
+func (c *Client) ProxyGroupUpdate(ctx context.Context, id string, group ProxyGroup) error {
+	if err := c.requireProxyGroups(ctx); err != nil {
+		return err
+	}
+	params := proxyGroupParams(group)
+	params["proxy_groupid"] = id
+	var ignored any
+	return c.callAuth(ctx, "proxygroup.update", params, &ignored)
+}
+
+func (c *Client) ProxyGroupDelete(ctx context.Context, id string) error {
+	var ignored any
+	return c.callAuth(ctx, "proxygroup.delete", []string{id}, &ignored)
+}
@@ -0,0 +1,430 @@
+package zabbix
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Parser for the trigger and calculated item expression syntax of Zabbix 5.4+, e.g.
+//
+//	last(/web01/system.cpu.load[all,avg1])>{$LOAD.MAX} and nodata(/web01/agent.ping,5m)=0
+//
+// It checks the syntax (operators, functions, /host/key item queries, macros, strings, numbers with
+// suffixes and periods such as #3 or 1h:now/h), reports the offset of the first error, collects the
+// referenced items and rebuilds the expression with canonical whitespace. Function names and their
+// parameters are not checked: they depend on the Zabbix version.
+
+// ExpressionError is a syntax error at a byte offset of the expression.
+type ExpressionError struct {
+	Offset  int
+	Message string
+}
+
+func (e *ExpressionError) Error() string {
+	return fmt.Sprintf("%s at offset %d", e.Message, e.Offset)
+}
+
+// ItemReference is an item query of an expression: /Host/key. Host is empty for "//key" (calculated items)
+// and may be a macro such as {HOST.HOST} or "*" in aggregate functions.
+type ItemReference struct {
+	Host   string
+	Key    string
+	Offset int
+}
+
+// Expression is a parsed expression.
+type Expression struct {
+	// Normalized is the expression with canonical whitespace: none around symbols and single spaces
+	// around the and/or/not keywords. Strings, macros and item keys are kept as written.
+	Normalized string
+	References []ItemReference
+}
+
+// ParseExpression parses a Zabbix 5.4+ expression.
+func ParseExpression(expr string) (*Expression, error) {
+	p := &expressionParser{src: expr}
+	p.skipSpace()
+	if p.eof() {
+		return nil, &ExpressionError{Offset: 0, Message: "empty expression"}
+	}
+	out, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	p.skipSpace()
+	if !p.eof() {
+		return nil, p.errorf(p.pos, "unexpected %q", p.src[p.pos:p.pos+1])
+	}
+	return &Expression{Normalized: out, References: p.refs}, nil
+}
+
+// NormalizeExpression returns the expression with canonical whitespace, or the trimmed expression if it
+// does not parse. Two expressions that only differ by whitespace normalize to the same string.
+func NormalizeExpression(expr string) string {
+	parsed, err := ParseExpression(expr)
+	if err != nil {
+		return strings.TrimSpace(expr)
+	}
+	return parsed.Normalized
+}
+
+var (
+	expressionNumber     = regexp.MustCompile(`^(\d+(\.\d*)?|\.\d+)([eE][+-]?\d+)?[KMGTsmhdw]?`)
+	expressionPeriod     = regexp.MustCompile(`^(#\d+|\d+[smhdwMy]?)(:now[-+/0-9a-zA-Z]*)?`)
+	expressionIdentifier = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*`)
+	expressionKeyName    = regexp.MustCompile(`^[a-zA-Z0-9_.\-*]+`)
+)
+
+type expressionParser struct {
+	src  string
+	pos  int
+	refs []ItemReference
+}
+
+func (p *expressionParser) eof() bool {
+	return p.pos >= len(p.src)
+}
+
+func (p *expressionParser) errorf(offset int, format string, args ...any) error {
+	return &ExpressionError{Offset: offset, Message: fmt.Sprintf(format, args...)}
+}
+
+func (p *expressionParser) skipSpace() {
+	for !p.eof() && strings.IndexByte(" \t\r\n", p.src[p.pos]) >= 0 {
+		p.pos++
+	}
+}
+
+// keyword consumes a keyword operator (and, or, not) if it is not the prefix of a longer identifier.
+func (p *expressionParser) keyword(word string) bool {
+	if !strings.HasPrefix(p.src[p.pos:], word) {
+		return false
+	}
+	end := p.pos + len(word)
+	if end < len(p.src) && isIdentifierChar(p.src[end]) {
+		return false
+	}
+	p.pos = end
+	return true
+}
+
+// operator consumes the first of the symbol operators found at the current position.
+func (p *expressionParser) operator(ops ...string) (string, bool) {
+	for _, op := range ops {
+		if strings.HasPrefix(p.src[p.pos:], op) {
+			p.pos += len(op)
+			return op, true
+		}
+	}
+	return "", false
+}
+
+func isIdentifierChar(c byte) bool {
+	return c == '_' || c >= '0' && c <= '9' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
+
+// Operator precedence, lowest first: or, and, = <>, < <= > >=, + -, * /, unary - and not.
+
+func (p *expressionParser) parseOr() (string, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return "", err
+	}
+	for {
+		p.skipSpace()
+		if !p.keyword("or") {
+			return left, nil
+		}
+		right, err := p.parseAnd()
+		if err != nil {
+			return "", err
+		}
+		left += " or " + right
+	}
+}
+
+func (p *expressionParser) parseAnd() (string, error) {
+	left, err := p.parseBinary(0)
+	if err != nil {
+		return "", err
+	}
+	for {
+		p.skipSpace()
+		if !p.keyword("and") {
+			return left, nil
+		}
+		right, err := p.parseBinary(0)
+		if err != nil {
+			return "", err
+		}
+		left += " and " + right
+	}
+}
+
+// expressionOperators lists the symbol operators by precedence level; longer operators come first so
+// that "<=" is not read as "<". Higher levels stop at "<>" for the same reason.
+var expressionOperators = [][]string{
+	{"=", "<>"},
+	{"<=", ">=", "<", ">"},
+	{"+", "-"},
+	{"*", "/"},
+}
+
+func (p *expressionParser) parseBinary(level int) (string, error) {
+	if level == len(expressionOperators) {
+		return p.parseUnary()
+	}
+	left, err := p.parseBinary(level + 1)
+	if err != nil {
+		return "", err
+	}
+	for {
+		p.skipSpace()
+		if level > 0 && strings.HasPrefix(p.src[p.pos:], "<>") {
+			return left, nil
+		}
+		op, ok := p.operator(expressionOperators[level]...)
+		if !ok {
+			return left, nil
+		}
+		right, err := p.parseBinary(level + 1)
+		if err != nil {
+			return "", err
+		}
+		left += op + right
+	}
+}
+
+func (p *expressionParser) parseUnary() (string, error) {
+	p.skipSpace()
+	if _, ok := p.operator("-"); ok {
+		operand, err := p.parseUnary()
+		if err != nil {
+			return "", err
+		}
+		return "-" + operand, nil
+	}
+	if p.keyword("not") {
+		operand, err := p.parseUnary()
+		if err != nil {
+			return "", err
+		}
+		return "not " + operand, nil
+	}
+	return p.parsePrimary()
+}
+
+func (p *expressionParser) parsePrimary() (string, error) {
+	p.skipSpace()
+	if p.eof() {
+		return "", p.errorf(p.pos, "unexpected end of expression")
+	}
+	start := p.pos
+	switch c := p.src[p.pos]; {
+	case c == '(':
+		p.pos++
+		inner, err := p.parseOr()
+		if err != nil {
+			return "", err
+		}
+		p.skipSpace()
+		if p.eof() || p.src[p.pos] != ')' {
+			return "", p.errorf(start, "missing closing parenthesis")
+		}
+		p.pos++
+		return "(" + inner + ")", nil
+	case c == '"':
+		return p.parseString()
+	case c == '{':
+		return p.parseMacro()
+	case c >= '0' && c <= '9' || c == '.':
+		return p.parseNumber()
+	case c == '/':
+		return "", p.errorf(start, "item query outside of a function")
+	}
+
+	name := expressionIdentifier.FindString(p.src[p.pos:])
+	if name == "" {
+		return "", p.errorf(start, "unexpected %q", p.src[p.pos:p.pos+1])
+	}
+	p.pos += len(name)
+	if p.eof() || p.src[p.pos] != '(' {
+		return "", p.errorf(start, "unknown token %q, expected a function call", name)
+	}
+	return p.parseFunction(name, start)
+}
+
+func (p *expressionParser) parseFunction(name string, start int) (string, error) {
+	p.pos++ // (
+	args := make([]string, 0)
+	p.skipSpace()
+	if !p.eof() && p.src[p.pos] == ')' {
+		p.pos++
+		return name + "()", nil
+	}
+	for {
+		arg, err := p.parseArgument()
+		if err != nil {
+			return "", err
+		}
+		args = append(args, arg)
+		p.skipSpace()
+		if p.eof() {
+			return "", p.errorf(start, "missing closing parenthesis of %s()", name)
+		}
+		switch p.src[p.pos] {
+		case ',':
+			p.pos++
+		case ')':
+			p.pos++
+			return name + "(" + strings.Join(args, ",") + ")", nil
+		default:
+			return "", p.errorf(p.pos, "expected ',' or ')' in %s()", name)
+		}
+	}
+}
+
+// parseArgument parses a function parameter: an item query, a period (#3, 1h:now/h), an expression,
+// or nothing.
+func (p *expressionParser) parseArgument() (string, error) {
+	p.skipSpace()
+	if p.eof() {
+		return "", p.errorf(p.pos, "unexpected end of expression")
+	}
+	switch p.src[p.pos] {
+	case ',', ')':
+		return "", nil
+	case '/':
+		return p.parseItemQuery()
+	}
+	if period := expressionPeriod.FindString(p.src[p.pos:]); period != "" && (period[0] == '#' || strings.Contains(period, ":")) {
+		p.pos += len(period)
+		return period, nil
+	}
+	return p.parseOr()
+}
+
+// parseItemQuery parses /host/key, /host/key[params] and the /*/key?[filter] form of aggregate functions.
+func (p *expressionParser) parseItemQuery() (string, error) {
+	start := p.pos
+	p.pos++ // /
+	hostEnd := strings.IndexAny(p.src[p.pos:], "/,)")
+	if hostEnd < 0 || p.src[p.pos+hostEnd] != '/' {
+		return "", p.errorf(start, "invalid item query, expected /host/key")
+	}
+	host := p.src[p.pos : p.pos+hostEnd]
+	if strings.TrimSpace(host) != host {
+		return "", p.errorf(p.pos, "invalid host name %q", host)
+	}
+	p.pos += hostEnd + 1
+
+	keyStart := p.pos
+	if !p.eof() && p.src[p.pos] == '{' {
+		if _, err := p.parseMacro(); err != nil {
+			return "", err
+		}
+	} else {
+		name := expressionKeyName.FindString(p.src[p.pos:])
+		if name == "" {
+			return "", p.errorf(p.pos, "missing item key")
+		}
+		p.pos += len(name)
+	}
+	if !p.eof() && p.src[p.pos] == '[' {
+		if err := p.skipBrackets(); err != nil {
+			return "", err
+		}
+	}
+	key := p.src[keyStart:p.pos]
+	if strings.HasPrefix(p.src[p.pos:], "?[") {
+		p.pos++
+		if err := p.skipBrackets(); err != nil {
+			return "", err
+		}
+	}
+	p.refs = append(p.refs, ItemReference{Host: host, Key: key, Offset: start})
+	return p.src[start:p.pos], nil
+}
+
+// skipBrackets skips a [...] block with nested brackets and quoted strings, as in item key parameters.
+func (p *expressionParser) skipBrackets() error {
+	start := p.pos
+	depth := 0
+	for !p.eof() {
+		switch p.src[p.pos] {
+		case '"':
+			if _, err := p.parseString(); err != nil {
+				return err
+			}
+			continue
+		case '[':
+			depth++
+		case ']':
+			depth--
+			if depth == 0 {
+				p.pos++
+				return nil
+			}
+		}
+		p.pos++
+	}
+	return p.errorf(start, "missing closing bracket")
+}
+
+func (p *expressionParser) parseString() (string, error) {
+	start := p.pos
+	p.pos++ // "
+	for !p.eof() {
+		switch p.src[p.pos] {
+		case '\\':
+			p.pos += 2
+			continue
+		case '"':
+			p.pos++
+			return p.src[start:p.pos], nil
+		}
+		p.pos++
+	}
+	return "", p.errorf(start, "unterminated string")
+}
+
+// parseMacro parses a user macro ({$NAME}, {$NAME:"context"}), an LLD macro ({#NAME}) or a built-in
+// macro ({HOST.HOST}, {TRIGGER.VALUE}).
+func (p *expressionParser) parseMacro() (string, error) {
+	start := p.pos
+	p.pos++ // {
+	for !p.eof() {
+		switch p.src[p.pos] {
+		case '"':
+			if _, err := p.parseString(); err != nil {
+				return "", err
+			}
+			continue
+		case '{':
+			return "", p.errorf(p.pos, "unexpected '{' in macro")
+		case '}':
+			p.pos++
+			macro := p.src[start:p.pos]
+			if len(macro) < 3 || strings.IndexByte("$#", macro[1]) < 0 && (macro[1] < 'A' || macro[1] > 'Z') {
+				return "", p.errorf(start, "invalid macro %s", macro)
+			}
+			return macro, nil
+		}
+		p.pos++
+	}
+	return "", p.errorf(start, "unterminated macro")
+}
+
+func (p *expressionParser) parseNumber() (string, error) {
+	start := p.pos
+	number := expressionNumber.FindString(p.src[p.pos:])
+	if number == "" {
+		return "", p.errorf(start, "invalid number")
+	}
+	p.pos += len(number)
+	if !p.eof() && (isIdentifierChar(p.src[p.pos]) || p.src[p.pos] == '.') {
+		return "", p.errorf(start, "invalid number %q", number+p.src[p.pos:p.pos+1])
+	}
+	return number, nil
+}
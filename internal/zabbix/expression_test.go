@@ -0,0 +1,148 @@
+package zabbix
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestParseExpression(t *testing.T) {
+	tests := []struct {
+		name       string
+		expr       string
+		normalized string
+		references []ItemReference
+	}{
+		{
+			name:       "function and macro",
+			expr:       "last(/web01/system.cpu.load[all,avg1]) > {$LOAD.MAX} and nodata(/web01/agent.ping, 5m) = 0",
+			normalized: "last(/web01/system.cpu.load[all,avg1])>{$LOAD.MAX} and nodata(/web01/agent.ping,5m)=0",
+			references: []ItemReference{
+				{Host: "web01", Key: "system.cpu.load[all,avg1]", Offset: 5},
+				{Host: "web01", Key: "agent.ping", Offset: 64},
+			},
+		},
+		{
+			name:       "quoted key parameters",
+			expr:       `last(/db01/vfs.fs.size["/var, [log]",pfree]) < 10`,
+			normalized: `last(/db01/vfs.fs.size["/var, [log]",pfree])<10`,
+			references: []ItemReference{{Host: "db01", Key: `vfs.fs.size["/var, [log]",pfree]`, Offset: 5}},
+		},
+		{
+			name:       "lld and context macros",
+			expr:       `last(/{HOST.HOST}/vfs.fs.size[{#FSNAME},pused]) > {$PUSED.MAX:"{#FSNAME}"}`,
+			normalized: `last(/{HOST.HOST}/vfs.fs.size[{#FSNAME},pused])>{$PUSED.MAX:"{#FSNAME}"}`,
+			references: []ItemReference{{Host: "{HOST.HOST}", Key: "vfs.fs.size[{#FSNAME},pused]", Offset: 5}},
+		},
+		{
+			name:       "periods and suffixes",
+			expr:       `avg(/h/k, 1h:now/h) > 1.5K or count(/h/k, #3, "gt", 0) >= 2 or max(/h/k, 10m) > 5s`,
+			normalized: `avg(/h/k,1h:now/h)>1.5K or count(/h/k,#3,"gt",0)>=2 or max(/h/k,10m)>5s`,
+			references: []ItemReference{
+				{Host: "h", Key: "k", Offset: 4},
+				{Host: "h", Key: "k", Offset: 36},
+				{Host: "h", Key: "k", Offset: 67},
+			},
+		},
+		{
+			name:       "not and parentheses",
+			expr:       "not ( last(/h/k) = 1 or last(/h/k) = 2 ) and -last(/h/k)<>-1",
+			normalized: "not (last(/h/k)=1 or last(/h/k)=2) and -last(/h/k)<>-1",
+			references: []ItemReference{
+				{Host: "h", Key: "k", Offset: 11},
+				{Host: "h", Key: "k", Offset: 29},
+				{Host: "h", Key: "k", Offset: 51},
+			},
+		},
+		{
+			name:       "calculated item and aggregate",
+			expr:       `sum(//net.if.in[eth0]) * 8 + avg(last_foreach(/*/cpu.util?[group="Linux"]))`,
+			normalized: `sum(//net.if.in[eth0])*8+avg(last_foreach(/*/cpu.util?[group="Linux"]))`,
+			references: []ItemReference{
+				{Host: "", Key: "net.if.in[eth0]", Offset: 4},
+				{Host: "*", Key: "cpu.util", Offset: 46},
+			},
+		},
+		{
+			name:       "keyword prefix of a function name",
+			expr:       "notify(/h/k)=1 or order()=2",
+			normalized: "notify(/h/k)=1 or order()=2",
+			references: []ItemReference{{Host: "h", Key: "k", Offset: 7}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parsed, err := ParseExpression(tt.expr)
+			if err != nil {
+				t.Fatalf("ParseExpression(%q) error: %v", tt.expr, err)
+			}
+			if parsed.Normalized != tt.normalized {
+				t.Errorf("Normalized = %q, want %q", parsed.Normalized, tt.normalized)
+			}
+			if !reflect.DeepEqual(parsed.References, tt.references) {
+				t.Errorf("References = %+v, want %+v", parsed.References, tt.references)
+			}
+		})
+	}
+}
+
+func TestParseExpressionErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		expr    string
+		offset  int
+		message string
+	}{
+		{"empty", "  ", 0, "empty expression"},
+		{"uppercase OR", "last(/h/k)>0 OR last(/h/k)<1", 13, `unexpected "O"`},
+		{"uppercase AND", "last(/h/k)>0 AND 1", 13, `unexpected "A"`},
+		{"unclosed parenthesis", "(last(/h/k)>0", 0, "missing closing parenthesis"},
+		{"extra parenthesis", "last(/h/k)>0)", 12, `unexpected ")"`},
+		{"unclosed function", "last(/h/k", 0, "missing closing parenthesis of last()"},
+		{"missing operand", "last(/h/k)>", 11, "unexpected end of expression"},
+		{"bare item query", "/h/k>0", 0, "item query outside of a function"},
+		{"unknown token", "1=foo", 2, `unknown token "foo", expected a function call`},
+		{"invalid item query", "last(/h)", 5, "invalid item query, expected /host/key"},
+		{"missing item key", "last(/h/)", 8, "missing item key"},
+		{"unclosed key parameters", "last(/h/k[a)", 9, "missing closing bracket"},
+		{"unterminated string", `find(/h/k,,"eq)`, 11, "unterminated string"},
+		{"unterminated macro", "last(/h/k)>{$MAX", 11, "unterminated macro"},
+		{"invalid macro", "last(/h/k)>{x}", 11, "invalid macro {x}"},
+		{"invalid number", "last(/h/k)>1x", 11, `invalid number "1x"`},
+		{"missing separator", "last(/h/k 1)", 10, "expected ',' or ')' in last()"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseExpression(tt.expr)
+			var exprErr *ExpressionError
+			if !errors.As(err, &exprErr) {
+				t.Fatalf("ParseExpression(%q) error = %v, want an *ExpressionError", tt.expr, err)
+			}
+			if exprErr.Offset != tt.offset || exprErr.Message != tt.message {
+				t.Errorf("error = %q at offset %d, want %q at offset %d", exprErr.Message, exprErr.Offset, tt.message, tt.offset)
+			}
+		})
+	}
+}
+
+func TestNormalizeExpression(t *testing.T) {
+	tests := []struct {
+		expr string
+		want string
+	}{
+		{"last(/h/k)>0", "last(/h/k)>0"},
+		{" last( /h/k )\t>\n0 ", "last(/h/k)>0"},
+		{"last(/h/k)=1   or\tnot last(/h/k)=2", "last(/h/k)=1 or not last(/h/k)=2"},
+		{`find(/h/k,,"like","a  b")=1`, `find(/h/k,,"like","a  b")=1`},
+		{"  last(/h/k) OR 1  ", "last(/h/k) OR 1"},
+	}
+	for _, tt := range tests {
+		got := NormalizeExpression(tt.expr)
+		if got != tt.want {
+			t.Errorf("NormalizeExpression(%q) = %q, want %q", tt.expr, got, tt.want)
+		}
+		if again := NormalizeExpression(got); again != got {
+			t.Errorf("NormalizeExpression(%q) = %q, not stable after %q", got, again, tt.expr)
+		}
+	}
+}